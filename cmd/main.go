@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/AthulKrishna2501/zyra-client-service/internals/app/config"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/app/grpc"
+	"github.com/AthulKrishna2501/zyra-client-service/internals/app/scheduler"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/core/cloudinary"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/core/database"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/core/repository"
@@ -20,6 +21,11 @@ func main() {
 		return
 	}
 
+	if configEnv.QR_KEY_SECRET == "" {
+		log.Error("QR_KEY_SECRET must be set to sign ticket QR codes")
+		return
+	}
+
 	cloudinary.InitCloudinary(configEnv)
 	log.Info("Cloudinary Initiated successfully")
 
@@ -39,6 +45,8 @@ func main() {
 		return
 	}
 
+	scheduler.StartScheduler(ClientRepo, log, configEnv)
+
 	router := gin.Default()
 	log.Info("HTTP Server started on port 3005")
 	router.Static("/", "./internals/static")
@@ -13,17 +13,24 @@ import (
 )
 
 type Config struct {
-	PORT                  string `mapstructure:"PORT"`
-	DB_URL                string `mapstructure:"DB_URL"`
-	STRIPE_SECRET_KEY     string `mapstructure:"STRIPE_SECRET_KEY"`
-	STRIPE_WEBHOOK_SECRET string `mapstructure:"STRIPE_WEBHOOK_SECRET"`
-	ADMIN_EMAIL           string `mapstructure:"ADMIN_EMAIL"`
-	STRIPE_SUCCESS_URL    string `mapstructure:"STRIPE_SUCCESS_URL"`
-	STRIPE_CANCEL_URL     string `mapstructure:"STRIPE_CANCEL_URL"`
-	CLOUD_NAME            string `mapstructure:"CLOUD_NAME"`
-	CLOUD_API_KEY         string `mapstructure:"CLOUD_API_KEY"`
-	CLOUD_SECRET          string `mapstructure:"CLOUD_SECRET"`
-	SECRET_NAME           string `mapstructure:"SECRET_NAME"`
+	PORT                  string  `mapstructure:"PORT"`
+	DB_URL                string  `mapstructure:"DB_URL"`
+	STRIPE_SECRET_KEY     string  `mapstructure:"STRIPE_SECRET_KEY"`
+	STRIPE_WEBHOOK_SECRET string  `mapstructure:"STRIPE_WEBHOOK_SECRET"`
+	ADMIN_EMAIL           string  `mapstructure:"ADMIN_EMAIL"`
+	STRIPE_SUCCESS_URL    string  `mapstructure:"STRIPE_SUCCESS_URL"`
+	STRIPE_CANCEL_URL     string  `mapstructure:"STRIPE_CANCEL_URL"`
+	CLOUD_NAME            string  `mapstructure:"CLOUD_NAME"`
+	CLOUD_API_KEY         string  `mapstructure:"CLOUD_API_KEY"`
+	CLOUD_SECRET          string  `mapstructure:"CLOUD_SECRET"`
+	SECRET_NAME           string  `mapstructure:"SECRET_NAME"`
+	RELEASE_GRACE_DAYS    int     `mapstructure:"RELEASE_GRACE_DAYS"`
+	VENDOR_RESPONSE_HOURS int     `mapstructure:"VENDOR_RESPONSE_HOURS"`
+	BOOKING_TIMEZONE      string  `mapstructure:"BOOKING_TIMEZONE"`
+	QR_KEY_SECRET         string  `mapstructure:"QR_KEY_SECRET"`
+	QR_KEY_ROTATION_DAYS  int     `mapstructure:"QR_KEY_ROTATION_DAYS"`
+	EVENT_COMMISSION_RATE float64 `mapstructure:"EVENT_COMMISSION_RATE"`
+	PAYMENT_FEE_RATE      float64 `mapstructure:"PAYMENT_FEE_RATE"`
 }
 
 func LoadConfig() (cfg Config, err error) {
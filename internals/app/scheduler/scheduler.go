@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/AthulKrishna2501/zyra-client-service/internals/app/config"
+	"github.com/AthulKrishna2501/zyra-client-service/internals/core/repository"
+	"github.com/AthulKrishna2501/zyra-client-service/internals/core/services"
+	"github.com/AthulKrishna2501/zyra-client-service/internals/logger"
+)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func StartScheduler(ClientRepo repository.ClientRepository, log logger.Logger, cfg config.Config) {
+	ClientService := services.NewClientService(ClientRepo, cfg, log)
+
+	jobs := []job{
+		{name: "escrow auto release", interval: time.Hour, run: ClientService.AutoReleaseEscrow},
+		{name: "vendor response timeout", interval: 15 * time.Minute, run: ClientService.ExpireUnansweredBookings},
+		{name: "event cancellation refunds", interval: 5 * time.Minute, run: ClientService.ProcessEventCancellations},
+		{name: "qr signing key rotation", interval: 24 * time.Hour, run: ClientService.RotateQRSigningKey},
+		{name: "legacy qr signing", interval: time.Hour, run: ClientService.SignLegacyTicketQRs},
+		{name: "event publishing and archival", interval: time.Minute, run: ClientService.ProcessEventLifecycle},
+	}
+
+	for _, j := range jobs {
+		go runJob(j, log)
+	}
+
+	log.Info("Scheduler started")
+}
+
+func runJob(j job, log logger.Logger) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.run(context.Background()); err != nil {
+			log.Error("Scheduled job failed: "+j.name, err)
+		}
+		<-ticker.C
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"path"
+	"strings"
 
 	"github.com/AthulKrishna2501/zyra-client-service/internals/app/config"
 	"github.com/cloudinary/cloudinary-go/v2"
@@ -23,13 +25,17 @@ func InitCloudinary(cfg config.Config) {
 }
 
 func UploadImage(filePath string) (string, *uploader.UploadResult, error) {
+	return UploadToFolder(filePath, "event_posters")
+}
+
+func UploadToFolder(filePath, folder string) (string, *uploader.UploadResult, error) {
 	if cld == nil {
 		return "", nil, fmt.Errorf("cloudinary not initialized")
 	}
 
 	ctx := context.Background()
 	resp, err := cld.Upload.Upload(ctx, filePath, uploader.UploadParams{
-		Folder: "event_posters",
+		Folder: folder,
 	})
 	if err != nil {
 		return "", nil, err
@@ -52,6 +58,26 @@ func GetImageURL(publicID string) (string, error) {
 	return asset.String()
 }
 
+// PublicIDFromURL recovers the public ID from a delivery URL such as
+// https://res.cloudinary.com/<cloud>/image/upload/v123/event_posters/abc.jpg.
+func PublicIDFromURL(url string) string {
+	_, path, found := strings.Cut(url, "/upload/")
+	if !found {
+		return ""
+	}
+
+	segments := strings.Split(path, "/")
+	if len(segments) > 1 && strings.HasPrefix(segments[0], "v") {
+		segments = segments[1:]
+	}
+
+	publicID := strings.Join(segments, "/")
+	if dot := strings.LastIndex(publicID, "."); dot > 0 {
+		publicID = publicID[:dot]
+	}
+	return publicID
+}
+
 func DeleteImage(publicID string) error {
 	if cld == nil {
 		return fmt.Errorf("cloudinary not initialized")
@@ -61,3 +87,79 @@ func DeleteImage(publicID string) error {
 	_, err := cld.Upload.Destroy(ctx, uploader.DestroyParams{PublicID: publicID})
 	return err
 }
+
+// UploadMedia uploads an image or video, letting Cloudinary detect the type.
+func UploadMedia(filePath, folder string) (*uploader.UploadResult, error) {
+	if cld == nil {
+		return nil, fmt.Errorf("cloudinary not initialized")
+	}
+
+	ctx := context.Background()
+	return cld.Upload.Upload(ctx, filePath, uploader.UploadParams{
+		Folder:       folder,
+		ResourceType: "auto",
+	})
+}
+
+var videoExtensions = map[string]bool{
+	".mp4": true, ".mov": true, ".webm": true, ".avi": true, ".mkv": true, ".m4v": true,
+}
+
+// IsVideoSource reports whether an upload source is recognisably a video,
+// from its data URI media type or its file extension.
+func IsVideoSource(source string) bool {
+	if strings.HasPrefix(source, "data:") {
+		return strings.HasPrefix(source, "data:video/")
+	}
+
+	if i := strings.IndexAny(source, "?#"); i >= 0 {
+		source = source[:i]
+	}
+	return videoExtensions[strings.ToLower(path.Ext(source))]
+}
+
+// Duration returns the length in seconds Cloudinary reported for an uploaded
+// video, or 0 when the response has none.
+func Duration(result *uploader.UploadResult) float64 {
+	response, ok := result.Response.(*map[string]interface{})
+	if !ok || response == nil {
+		return 0
+	}
+
+	duration, _ := (*response)["duration"].(float64)
+	return duration
+}
+
+// TransformedURL builds a delivery URL for the asset with a raw transformation
+// such as "c_fill,w_320,h_180". Video URLs get the format appended, so a
+// "jpg" format returns a still frame of the video.
+func TransformedURL(publicID, resourceType, transformation, format string) (string, error) {
+	if cld == nil {
+		return "", fmt.Errorf("cloudinary not initialized")
+	}
+
+	if format != "" {
+		publicID += "." + format
+	}
+
+	asset, err := cld.Image(publicID)
+	if resourceType == "video" {
+		asset, err = cld.Video(publicID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	asset.Transformation = transformation
+	return asset.String()
+}
+
+func DeleteMedia(publicID, resourceType string) error {
+	if cld == nil {
+		return fmt.Errorf("cloudinary not initialized")
+	}
+
+	ctx := context.Background()
+	_, err := cld.Upload.Destroy(ctx, uploader.DestroyParams{PublicID: publicID, ResourceType: resourceType})
+	return err
+}
@@ -27,14 +27,14 @@ func ConnectDatabase(env config.Config) *gorm.DB {
 }
 
 func AutoMigrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&models.Event{}); err != nil {
+	if err := addEventStatus(db); err != nil {
 		return err
 	}
-	if err := db.AutoMigrate(&models.EventDetails{}); err != nil {
+
+	if err := db.AutoMigrate(&models.Event{}); err != nil {
 		return err
 	}
-
-	if err := db.AutoMigrate(&models.Location{}); err != nil {
+	if err := db.AutoMigrate(&models.EventDetails{}); err != nil {
 		return err
 	}
 
@@ -53,5 +53,155 @@ func AutoMigrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(&models.QR{}); err != nil {
 		return err
 	}
+
+	if err := db.AutoMigrate(&models.QuoteRequest{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.Quote{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.Notification{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.ReleasePolicy{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.BookingCategory{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.Dispute{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.DisputeAttachment{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.BookingDeadline{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.CartItem{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.Order{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.OrderItem{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.BookingPolicy{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.EventCancellation{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.TicketTier{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.QRSigningKey{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.ScanRecord{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.TicketTransfer{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.EventSeries{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.EventSettlement{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.EventMember{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.EventAuditLog{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.EventMedia{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.Venue{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&models.VenuePhoto{}); err != nil {
+		return err
+	}
+
+	if err := backfillEventSchedule(db); err != nil {
+		return err
+	}
+
+	return createSearchIndexes(db)
+}
+
+// addEventStatus adds the status column to an existing events table. Events
+// created before drafts existed were all live, so they are published and only
+// events created afterwards start out as drafts.
+func addEventStatus(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Event{}) || db.Migrator().HasColumn(&models.Event{}, "Status") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`ALTER TABLE events ADD COLUMN status varchar(50) NOT NULL DEFAULT 'published'`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`ALTER TABLE events ALTER COLUMN status SET DEFAULT 'draft'`).Error
+	})
+}
+
+// backfillEventSchedule derives absolute start and end instants for events
+// created before they were stored, reading the legacy date and time columns
+// in the event's timezone. Events ending before they start ran past midnight.
+func backfillEventSchedule(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE events SET
+			starts_at = (events.date + event_details.start_time) AT TIME ZONE events.timezone,
+			ends_at = (events.date + event_details.end_time +
+				CASE WHEN event_details.end_time < event_details.start_time THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+			) AT TIME ZONE events.timezone
+		FROM event_details
+		WHERE event_details.event_id = events.event_id AND events.starts_at IS NULL`).Error
+}
+
+func createSearchIndexes(db *gorm.DB) error {
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_events_title_search ON events USING GIN (to_tsvector('english', title))`,
+		`CREATE INDEX IF NOT EXISTS idx_event_details_description_search ON event_details USING GIN (to_tsvector('english', COALESCE(description, '')))`,
+		`CREATE INDEX IF NOT EXISTS idx_events_city_date ON events (LOWER(location_city), date)`,
+		`CREATE INDEX IF NOT EXISTS idx_events_country_date ON events (LOWER(location_country), date)`,
+		`CREATE INDEX IF NOT EXISTS idx_event_details_price ON event_details (price_per_ticket, event_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_event_details_popularity ON event_details (tickets_sold DESC, event_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_events_location ON events (location_lat, location_lng)`,
+	}
+
+	for _, index := range indexes {
+		if err := db.Exec(index).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -21,15 +21,23 @@ type Transaction struct {
 }
 
 type Event struct {
-	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	EventID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
-	Title     string         `gorm:"type:varchar(255);not null"`
-	Location  Location       `json:"location" gorm:"embedded;embeddedPrefix:location_"`
-	HostedBy  uuid.UUID      `gorm:"type:uuid;not null;index"`
-	User      authModel.User `gorm:"foreignKey:HostedBy;references:UserID"`
-	Date      time.Time      `gorm:"type:date;not null;index"`
-	CreatedAt time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID     uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
+	Title       string         `gorm:"type:varchar(255);not null"`
+	Location    Location       `json:"location" gorm:"embedded;embeddedPrefix:location_"`
+	HostedBy    uuid.UUID      `gorm:"type:uuid;not null;index"`
+	User        authModel.User `gorm:"foreignKey:HostedBy;references:UserID"`
+	Date        time.Time      `gorm:"type:date;not null;index"`
+	Timezone    string         `gorm:"type:varchar(64);not null;default:'UTC'"`
+	StartsAt    time.Time      `gorm:"type:timestamptz;index"`
+	EndsAt      time.Time      `gorm:"type:timestamptz;index"`
+	Status      string         `gorm:"type:varchar(50);not null;default:'draft';index"`
+	PublishAt   *time.Time     `gorm:"type:timestamp;index"`
+	PublishedAt *time.Time     `gorm:"type:timestamp"`
+	SeriesID    *uuid.UUID     `gorm:"type:uuid;index"`
+	VenueID     *uuid.UUID     `gorm:"type:uuid;index"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
 }
 
 type Location struct {
@@ -41,15 +49,17 @@ type Location struct {
 }
 
 type EventDetails struct {
-	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	EventID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
-	Description    string    `gorm:"type:text"`
-	StartTime      time.Time `gorm:"type:time;not null"`
-	EndTime        time.Time `gorm:"type:time;not null"`
-	PosterImage    string    `gorm:"type:varchar(255)"`
-	PricePerTicket int       `gorm:"not null"`
-	TicketsSold    int       `gorm:"default:0"`
-	TicketLimit    int       `gorm:"not null"`
+	ID                  uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID             uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Description         string    `gorm:"type:text"`
+	StartTime           time.Time `gorm:"type:time;not null"`
+	EndTime             time.Time `gorm:"type:time;not null"`
+	PosterImage         string    `gorm:"type:varchar(255)"`
+	PosterPublicID      string    `gorm:"type:varchar(255)"`
+	PricePerTicket      int       `gorm:"not null"`
+	TicketsSold         int       `gorm:"default:0"`
+	TicketLimit         int       `gorm:"not null"`
+	TransferCutoffHours int       `gorm:"default:0"`
 
 	Event *Event `gorm:"foreignKey:EventID;references:EventID"`
 }
@@ -68,21 +78,316 @@ type Review struct {
 }
 
 type Ticket struct {
-	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
-	TicketID  string    `gorm:"type:varchar(255);unique;not null"`
-	ClientID  uuid.UUID `gorm:"type:uuid;not null"`
-	EventID   uuid.UUID `gorm:"type:uuid;not null"`
-	Status    string    `gorm:"type:varchar(255)"`
-	CreatedAt time.Time `gorm:"default:current_timestamp"`
-	UpdatedAt time.Time `gorm:"default:current_timestamp"`
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key"`
+	TicketID        string     `gorm:"type:varchar(255);unique;not null"`
+	ClientID        uuid.UUID  `gorm:"type:uuid;not null"`
+	EventID         uuid.UUID  `gorm:"type:uuid;not null"`
+	TierID          *uuid.UUID `gorm:"type:uuid;index"`
+	Status          string     `gorm:"type:varchar(255)"`
+	Amount          int        `gorm:"default:0"`
+	PaymentIntentID string     `gorm:"type:varchar(255)"`
+	CreatedAt       time.Time  `gorm:"default:current_timestamp"`
+	UpdatedAt       time.Time  `gorm:"default:current_timestamp"`
 }
 
 type QR struct {
 	ID          uuid.UUID  `gorm:"type:uuid;primary_key"`
 	UserID      uuid.UUID  `gorm:"type:uuid;not null"`
 	EventID     uuid.UUID  `gorm:"type:uuid;not null"`
+	TicketID    *uuid.UUID `gorm:"type:uuid;index"`
 	Code        string     `gorm:"type:text;not null;unique"`
+	Payload     string     `gorm:"type:text;index"`
 	GeneratedAt time.Time  `gorm:"default:current_timestamp"`
 	IsScanned   bool       `gorm:"default:false"`
 	ScannedAt   *time.Time `gorm:"type:timestamp"`
+	ScannedBy   *uuid.UUID `gorm:"type:uuid"`
+	ScanGate    string     `gorm:"type:varchar(100)"`
+	IsVoid      bool       `gorm:"default:false"`
+}
+
+type QuoteRequest struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	QuoteRequestID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	ClientID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	VendorID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	EventDate      time.Time `gorm:"type:date;not null"`
+	GuestCount     int       `gorm:"not null"`
+	Notes          string    `gorm:"type:text"`
+	Status         string    `gorm:"type:varchar(50);not null;index"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+
+	Quotes []Quote `gorm:"foreignKey:QuoteRequestID;references:QuoteRequestID"`
+}
+
+type Quote struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	QuoteID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	QuoteRequestID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VendorID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Title          string     `gorm:"type:varchar(255);not null"`
+	Description    string     `gorm:"type:text"`
+	Amount         int        `gorm:"not null"`
+	ExpiresAt      time.Time  `gorm:"not null"`
+	Status         string     `gorm:"type:varchar(50);not null;index"`
+	BookingID      *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+}
+
+type Notification struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Title     string    `gorm:"type:varchar(255);not null"`
+	Message   string    `gorm:"type:text"`
+	IsRead    bool      `gorm:"default:false"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+type ReleasePolicy struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CategoryID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	GraceDays  int       `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+type BookingCategory struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	BookingID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	CategoryID uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type Dispute struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	DisputeID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	BookingID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ClientID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VendorID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Reason         string     `gorm:"type:text;not null"`
+	VendorResponse string     `gorm:"type:text"`
+	Status         string     `gorm:"type:varchar(50);not null;index"`
+	Resolution     string     `gorm:"type:varchar(50)"`
+	RefundAmount   int        `gorm:"default:0"`
+	ResolvedBy     *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt     *time.Time `gorm:"type:timestamp"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+
+	Attachments []DisputeAttachment `gorm:"foreignKey:DisputeID;references:DisputeID"`
+}
+
+type DisputeAttachment struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	DisputeID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	UploadedBy uuid.UUID `gorm:"type:uuid;not null"`
+	PublicID   string    `gorm:"type:varchar(255)"`
+	URL        string    `gorm:"type:varchar(255);not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type BookingDeadline struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	BookingID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	RespondBy   time.Time  `gorm:"not null;index"`
+	Status      string     `gorm:"type:varchar(50);not null;index"`
+	RespondedAt *time.Time `gorm:"type:timestamp"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+}
+
+type CartItem struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CartItemID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	ClientID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	VendorID   uuid.UUID `gorm:"type:uuid;not null"`
+	ServiceID  uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type Order struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OrderID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	ClientID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Amount    int       `gorm:"not null"`
+	Status    string    `gorm:"type:varchar(50);not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	Items []OrderItem `gorm:"foreignKey:OrderID;references:OrderID"`
+}
+
+type OrderItem struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OrderItemID  uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	OrderID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VendorID     uuid.UUID  `gorm:"type:uuid;not null"`
+	ServiceID    uuid.UUID  `gorm:"type:uuid;not null"`
+	ServiceTitle string     `gorm:"type:varchar(255);not null"`
+	Date         time.Time  `gorm:"not null"`
+	Price        int        `gorm:"not null"`
+	Status       string     `gorm:"type:varchar(50);not null"`
+	BookingID    *uuid.UUID `gorm:"type:uuid"`
+}
+
+type BookingPolicy struct {
+	ID                        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Tier                      string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_policy_tier_service"`
+	ServiceType               string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_policy_tier_service"`
+	DailyLimit                int       `gorm:"default:0"`
+	WeeklyLimit               int       `gorm:"default:0"`
+	MaxPending                int       `gorm:"default:0"`
+	MaxTicketsPerEvent        int       `gorm:"default:0"`
+	CancellationCooldownHours int       `gorm:"default:0"`
+	UpdatedAt                 time.Time `gorm:"autoUpdateTime"`
+}
+
+type EventCancellation struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	HostID       uuid.UUID  `gorm:"type:uuid;not null"`
+	RefundTo     string     `gorm:"type:varchar(50);not null"`
+	Reason       string     `gorm:"type:text"`
+	Status       string     `gorm:"type:varchar(50);not null;index"`
+	Processed    int        `gorm:"default:0"`
+	Failed       int        `gorm:"default:0"`
+	ClaimedUntil *time.Time `gorm:"type:timestamp"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+}
+
+type TicketTier struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name          string     `gorm:"type:varchar(100);not null"`
+	Price         int        `gorm:"not null"`
+	Quantity      int        `gorm:"not null"`
+	Sold          int        `gorm:"default:0"`
+	PerOrderLimit int        `gorm:"default:0"`
+	SaleStart     *time.Time `gorm:"type:timestamp"`
+	SaleEnd       *time.Time `gorm:"type:timestamp"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime"`
+}
+
+type QRSigningKey struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	KeyID      string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	PublicKey  string     `gorm:"type:text;not null"`
+	PrivateKey string     `gorm:"type:text;not null"`
+	Status     string     `gorm:"type:varchar(20);not null;index"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+	RetiredAt  *time.Time `gorm:"type:timestamp"`
+}
+
+type ScanRecord struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	TicketID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	ScannerID  uuid.UUID `gorm:"type:uuid;not null"`
+	DeviceID   string    `gorm:"type:varchar(100)"`
+	Gate       string    `gorm:"type:varchar(100)"`
+	ScannedAt  time.Time `gorm:"not null"`
+	Result     string    `gorm:"type:varchar(50);not null"`
+	UploadedAt time.Time `gorm:"autoCreateTime"`
+}
+
+type TicketTransfer struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TicketID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	FromClientID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ToClientID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Status       string     `gorm:"type:varchar(50);not null;default:'pending';index"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	RespondedAt  *time.Time `gorm:"type:timestamp"`
+}
+
+type EventSeries struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SeriesID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	HostedBy      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Title         string     `gorm:"type:varchar(255);not null"`
+	Frequency     string     `gorm:"type:varchar(20);not null"`
+	Interval      int        `gorm:"default:1"`
+	StartDate     time.Time  `gorm:"type:date;not null"`
+	Until         *time.Time `gorm:"type:date"`
+	Count         int        `gorm:"default:0"`
+	ExcludedDates string     `gorm:"type:text"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime"`
+}
+
+type EventSettlement struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	HostID          uuid.UUID `gorm:"type:uuid;not null;index"`
+	FundReleaseID   uuid.UUID `gorm:"type:uuid"`
+	TicketsSold     int       `gorm:"not null"`
+	TicketsRefunded int       `gorm:"not null"`
+	GrossSales      int       `gorm:"not null"`
+	Refunds         int       `gorm:"not null"`
+	PaymentFeeRate  float64   `gorm:"not null"`
+	PaymentFees     int       `gorm:"not null"`
+	CommissionRate  float64   `gorm:"not null"`
+	Commission      int       `gorm:"not null"`
+	NetPayout       int       `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+}
+
+type EventMember struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_event_member"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_event_member;index"`
+	Role        string     `gorm:"type:varchar(50);not null"`
+	Status      string     `gorm:"type:varchar(50);not null;default:'pending';index"`
+	InvitedBy   uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
+	RespondedAt *time.Time `gorm:"type:timestamp"`
+}
+
+type EventAuditLog struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	ActorID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Action    string    `gorm:"type:varchar(100);not null"`
+	Details   string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+}
+
+type EventMedia struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	PublicID     string    `gorm:"type:varchar(255);not null"`
+	URL          string    `gorm:"type:varchar(500);not null"`
+	ResourceType string    `gorm:"type:varchar(20);not null"`
+	Format       string    `gorm:"type:varchar(20)"`
+	Width        int       `gorm:"default:0"`
+	Height       int       `gorm:"default:0"`
+	Bytes        int       `gorm:"default:0"`
+	Caption      string    `gorm:"type:varchar(500)"`
+	Position     int       `gorm:"not null;default:0"`
+	IsCover      bool      `gorm:"default:false"`
+	UploadedBy   uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+type Venue struct {
+	ID                 uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OwnerID            uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Name               string       `gorm:"type:varchar(255);not null"`
+	Location           Location     `json:"location" gorm:"embedded;embeddedPrefix:location_"`
+	Capacity           int          `gorm:"not null;default:0"`
+	AccessibilityNotes string       `gorm:"type:text"`
+	Photos             []VenuePhoto `gorm:"foreignKey:VenueID;references:ID"`
+	CreatedAt          time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time    `gorm:"autoUpdateTime"`
+}
+
+type VenuePhoto struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VenueID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	PublicID  string    `gorm:"type:varchar(255);not null"`
+	URL       string    `gorm:"type:varchar(500);not null"`
+	Position  int       `gorm:"not null;default:0"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
 }
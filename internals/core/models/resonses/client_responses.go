@@ -48,3 +48,52 @@ type BookingDetails struct {
 	PaymentID string
 
 }
+
+type CartItemDetails struct {
+	CartItemID    uuid.UUID
+	VendorID      uuid.UUID
+	ServiceID     uuid.UUID
+	FirstName     string
+	LastName      string
+	ServiceTitle  string
+	ServicePrice  int
+	AvailableDate time.Time
+}
+
+type EventTicketHolder struct {
+	ID        uuid.UUID
+	TicketID  string
+	ClientID  uuid.UUID
+	FirstName string
+	LastName  string
+	Email     string
+	TierName  string
+	Status    string
+	IsScanned bool
+	ScannedAt *time.Time
+	CreatedAt time.Time
+}
+
+type EventDistance struct {
+	EventID    uuid.UUID
+	DistanceKm float64
+}
+
+type DailySales struct {
+	Day     time.Time
+	Tickets int
+	Revenue int
+}
+
+type EventSalesTotals struct {
+	TicketsSold  int
+	GrossRevenue int
+}
+
+type TierSales struct {
+	TierID   uuid.UUID
+	TierName string
+	Quantity int
+	Sold     int
+	Revenue  int
+}
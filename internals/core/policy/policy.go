@@ -0,0 +1,127 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AthulKrishna2501/zyra-client-service/internals/core/models"
+)
+
+const Any = "*"
+
+type Usage struct {
+	Today            int
+	ThisWeek         int
+	Pending          int
+	EventTickets     int
+	LastCancellation *time.Time
+}
+
+type Violation struct {
+	Rule        string
+	Description string
+}
+
+var defaultPolicies = []models.BookingPolicy{
+	{Tier: Any, ServiceType: "vendor_booking", DailyLimit: 3},
+}
+
+// Resolve builds the policy for the tier and service type field by field.
+// Each limit comes from the most specific row that sets it, checking exact
+// matches before wildcards and stored rows before defaults, so a stored
+// wildcard row does not drop a default limit it leaves unset.
+func Resolve(policies []models.BookingPolicy, tier, serviceType string) models.BookingPolicy {
+	candidates := [][2]string{
+		{tier, serviceType},
+		{tier, Any},
+		{Any, serviceType},
+		{Any, Any},
+	}
+
+	resolved := models.BookingPolicy{Tier: tier, ServiceType: serviceType}
+	for _, set := range [][]models.BookingPolicy{policies, defaultPolicies} {
+		for _, c := range candidates {
+			for _, p := range set {
+				if p.Tier == c[0] && p.ServiceType == c[1] {
+					merge(&resolved, p)
+				}
+			}
+		}
+	}
+
+	return resolved
+}
+
+func merge(dst *models.BookingPolicy, src models.BookingPolicy) {
+	if dst.DailyLimit == 0 {
+		dst.DailyLimit = src.DailyLimit
+	}
+	if dst.WeeklyLimit == 0 {
+		dst.WeeklyLimit = src.WeeklyLimit
+	}
+	if dst.MaxPending == 0 {
+		dst.MaxPending = src.MaxPending
+	}
+	if dst.MaxTicketsPerEvent == 0 {
+		dst.MaxTicketsPerEvent = src.MaxTicketsPerEvent
+	}
+	if dst.CancellationCooldownHours == 0 {
+		dst.CancellationCooldownHours = src.CancellationCooldownHours
+	}
+}
+
+func Evaluate(rule models.BookingPolicy, usage Usage, quantity int, now time.Time) []Violation {
+	var violations []Violation
+
+	if rule.DailyLimit > 0 && usage.Today+quantity > rule.DailyLimit {
+		violations = append(violations, Violation{
+			Rule:        "daily_limit",
+			Description: fmt.Sprintf("daily limit of %d reached, %d already booked today", rule.DailyLimit, usage.Today),
+		})
+	}
+
+	if rule.WeeklyLimit > 0 && usage.ThisWeek+quantity > rule.WeeklyLimit {
+		violations = append(violations, Violation{
+			Rule:        "weekly_limit",
+			Description: fmt.Sprintf("weekly limit of %d reached, %d already booked this week", rule.WeeklyLimit, usage.ThisWeek),
+		})
+	}
+
+	if rule.MaxPending > 0 && usage.Pending+quantity > rule.MaxPending {
+		violations = append(violations, Violation{
+			Rule:        "max_pending",
+			Description: fmt.Sprintf("at most %d bookings can await vendor confirmation, %d are pending", rule.MaxPending, usage.Pending),
+		})
+	}
+
+	if rule.MaxTicketsPerEvent > 0 && usage.EventTickets+quantity > rule.MaxTicketsPerEvent {
+		violations = append(violations, Violation{
+			Rule:        "max_tickets_per_event",
+			Description: fmt.Sprintf("at most %d tickets per event, %d already held", rule.MaxTicketsPerEvent, usage.EventTickets),
+		})
+	}
+
+	if rule.CancellationCooldownHours > 0 && usage.LastCancellation != nil {
+		cooldownEnds := usage.LastCancellation.Add(time.Duration(rule.CancellationCooldownHours) * time.Hour)
+		if now.Before(cooldownEnds) {
+			violations = append(violations, Violation{
+				Rule:        "cancellation_cooldown",
+				Description: fmt.Sprintf("new bookings are blocked until %s after a recent cancellation", cooldownEnds.Format(time.RFC3339)),
+			})
+		}
+	}
+
+	return violations
+}
+
+func DayStart(now time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+// WeekStart returns midnight of the Monday of the current week in loc.
+func WeekStart(now time.Time, loc *time.Location) time.Time {
+	day := DayStart(now, loc)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
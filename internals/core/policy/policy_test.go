@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AthulKrishna2501/zyra-client-service/internals/core/models"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		policies    []models.BookingPolicy
+		tier        string
+		serviceType string
+		want        models.BookingPolicy
+	}{
+		{
+			name:        "defaults only",
+			tier:        "standard",
+			serviceType: "vendor_booking",
+			want:        models.BookingPolicy{Tier: "standard", ServiceType: "vendor_booking", DailyLimit: 3},
+		},
+		{
+			name:        "no default for service type",
+			tier:        "standard",
+			serviceType: "event_booking",
+			want:        models.BookingPolicy{Tier: "standard", ServiceType: "event_booking"},
+		},
+		{
+			name: "wildcard row keeps default daily cap",
+			policies: []models.BookingPolicy{
+				{Tier: Any, ServiceType: Any, WeeklyLimit: 10},
+			},
+			tier:        "standard",
+			serviceType: "vendor_booking",
+			want:        models.BookingPolicy{Tier: "standard", ServiceType: "vendor_booking", DailyLimit: 3, WeeklyLimit: 10},
+		},
+		{
+			name: "stored row overrides default",
+			policies: []models.BookingPolicy{
+				{Tier: Any, ServiceType: Any, DailyLimit: 5},
+			},
+			tier:        "standard",
+			serviceType: "vendor_booking",
+			want:        models.BookingPolicy{Tier: "standard", ServiceType: "vendor_booking", DailyLimit: 5},
+		},
+		{
+			name: "exact match wins per field",
+			policies: []models.BookingPolicy{
+				{Tier: Any, ServiceType: Any, DailyLimit: 1, MaxPending: 2},
+				{Tier: "master_of_ceremony", ServiceType: "vendor_booking", DailyLimit: 8},
+				{Tier: "master_of_ceremony", ServiceType: Any, CancellationCooldownHours: 12},
+			},
+			tier:        "master_of_ceremony",
+			serviceType: "vendor_booking",
+			want: models.BookingPolicy{
+				Tier:                      "master_of_ceremony",
+				ServiceType:               "vendor_booking",
+				DailyLimit:                8,
+				MaxPending:                2,
+				CancellationCooldownHours: 12,
+			},
+		},
+		{
+			name: "other tier ignored",
+			policies: []models.BookingPolicy{
+				{Tier: "master_of_ceremony", ServiceType: Any, DailyLimit: 8},
+			},
+			tier:        "standard",
+			serviceType: "vendor_booking",
+			want:        models.BookingPolicy{Tier: "standard", ServiceType: "vendor_booking", DailyLimit: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Resolve(tt.policies, tt.tier, tt.serviceType)
+			if got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2025, 5, 7, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name     string
+		rule     models.BookingPolicy
+		usage    Usage
+		quantity int
+		want     []string
+	}{
+		{
+			name:     "no limits",
+			usage:    Usage{Today: 50, ThisWeek: 50, Pending: 50},
+			quantity: 1,
+		},
+		{
+			name:     "under daily limit",
+			rule:     models.BookingPolicy{DailyLimit: 3},
+			usage:    Usage{Today: 2},
+			quantity: 1,
+		},
+		{
+			name:     "quantity crosses daily limit",
+			rule:     models.BookingPolicy{DailyLimit: 3},
+			usage:    Usage{Today: 2},
+			quantity: 2,
+			want:     []string{"daily_limit"},
+		},
+		{
+			name:     "weekly and pending",
+			rule:     models.BookingPolicy{WeeklyLimit: 5, MaxPending: 1},
+			usage:    Usage{ThisWeek: 5, Pending: 1},
+			quantity: 1,
+			want:     []string{"weekly_limit", "max_pending"},
+		},
+		{
+			name:     "tickets per event",
+			rule:     models.BookingPolicy{MaxTicketsPerEvent: 4},
+			usage:    Usage{EventTickets: 3},
+			quantity: 2,
+			want:     []string{"max_tickets_per_event"},
+		},
+		{
+			name:     "cooldown active",
+			rule:     models.BookingPolicy{CancellationCooldownHours: 24},
+			usage:    Usage{LastCancellation: &recent},
+			quantity: 1,
+			want:     []string{"cancellation_cooldown"},
+		},
+		{
+			name:     "cooldown over",
+			rule:     models.BookingPolicy{CancellationCooldownHours: 1},
+			usage:    Usage{LastCancellation: &recent},
+			quantity: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := Evaluate(tt.rule, tt.usage, tt.quantity, now)
+			if len(violations) != len(tt.want) {
+				t.Fatalf("Evaluate() = %+v, want rules %v", violations, tt.want)
+			}
+			for i, v := range violations {
+				if v.Rule != tt.want[i] {
+					t.Errorf("violation %d = %s, want %s", i, v.Rule, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	loc := time.FixedZone("IST", 5*3600+1800)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "midweek",
+			now:  time.Date(2025, 5, 7, 12, 0, 0, 0, loc),
+			want: time.Date(2025, 5, 5, 0, 0, 0, 0, loc),
+		},
+		{
+			name: "sunday belongs to previous monday",
+			now:  time.Date(2025, 5, 11, 23, 0, 0, 0, loc),
+			want: time.Date(2025, 5, 5, 0, 0, 0, 0, loc),
+		},
+		{
+			name: "utc instant on next local day",
+			now:  time.Date(2025, 5, 4, 20, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 5, 5, 0, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStart(tt.now, loc); !got.Equal(tt.want) {
+				t.Errorf("WeekStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
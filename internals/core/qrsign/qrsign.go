@@ -0,0 +1,168 @@
+package qrsign
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const prefix = "ZQR1"
+
+var (
+	ErrMalformed    = errors.New("malformed ticket code")
+	ErrUnknownKey   = errors.New("ticket code signed with an unknown key")
+	ErrBadSignature = errors.New("ticket code signature is invalid")
+)
+
+type Payload struct {
+	TicketID string `json:"tid"`
+	EventID  string `json:"eid"`
+	HolderID string `json:"hid"`
+	IssuedAt int64  `json:"iat"`
+	KeyID    string `json:"kid"`
+}
+
+func IsSigned(code string) bool {
+	return strings.HasPrefix(code, prefix+".")
+}
+
+// Sign encodes the payload as ZQR1.<payload>.<signature> using unpadded
+// base64url so the result fits comfortably in a medium density QR code.
+func Sign(payload Payload, key ed25519.PrivateKey) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(body)
+	signature := ed25519.Sign(key, []byte(prefix+"."+encoded))
+
+	return prefix + "." + encoded + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func Verify(code string, keys map[string]ed25519.PublicKey) (*Payload, error) {
+	parts := strings.Split(code, ".")
+	if len(parts) != 3 || parts[0] != prefix {
+		return nil, ErrMalformed
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrMalformed
+	}
+
+	key, ok := keys[payload.KeyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	if !ed25519.Verify(key, []byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrBadSignature
+	}
+
+	return &payload, nil
+}
+
+func GenerateKey(now time.Time) (string, ed25519.PublicKey, ed25519.PrivateKey, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	keyID := fmt.Sprintf("%s-%x", now.UTC().Format("20060102"), pub[:4])
+	return keyID, pub, priv, nil
+}
+
+func EncodePublicKey(key ed25519.PublicKey) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func DecodePublicKey(encoded string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// SealPrivateKey encrypts the private key with AES-GCM under a key derived
+// from secret so signing keys never sit in the database in the clear.
+func SealPrivateKey(secret string, key ed25519.PrivateKey) (string, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, key, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func OpenPrivateKey(secret, sealed string) (ed25519.PrivateKey, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid sealed private key")
+	}
+
+	key, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open private key: %w", err)
+	}
+
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key size")
+	}
+	return ed25519.PrivateKey(key), nil
+}
+
+func newGCM(secret string) (cipher.AEAD, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("QR key secret is not configured")
+	}
+
+	sum := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func SignBytes(data []byte, key ed25519.PrivateKey) string {
+	return base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, data))
+}
+
+func VerifyBytes(data []byte, signature string, key ed25519.PublicKey) bool {
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(key, data, sig)
+}
@@ -0,0 +1,261 @@
+package qrsign
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustKey(t *testing.T, now time.Time) (string, ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	keyID, pub, priv, err := GenerateKey(now)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return keyID, pub, priv
+}
+
+func mustSign(t *testing.T, payload Payload, key ed25519.PrivateKey) string {
+	t.Helper()
+	code, err := Sign(payload, key)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return code
+}
+
+// reencode swaps the payload of a signed code while keeping its signature.
+func reencode(t *testing.T, code string, mutate func(*Payload)) string {
+	t.Helper()
+	parts := strings.Split(code, ".")
+	body, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	mutate(&payload)
+
+	body, err = json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(body) + "." + parts[2]
+}
+
+func TestVerify(t *testing.T) {
+	oldID, oldPub, oldPriv := mustKey(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	newID, newPub, newPriv := mustKey(t, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	_, otherPub, otherPriv := mustKey(t, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	payload := Payload{TicketID: "t-1", EventID: "e-1", HolderID: "h-1", IssuedAt: 1746057600, KeyID: newID}
+	valid := mustSign(t, payload, newPriv)
+
+	oldPayload := payload
+	oldPayload.KeyID = oldID
+	signedWithOld := mustSign(t, oldPayload, oldPriv)
+
+	bothKeys := map[string]ed25519.PublicKey{oldID: oldPub, newID: newPub}
+
+	tests := []struct {
+		name    string
+		code    string
+		keys    map[string]ed25519.PublicKey
+		want    *Payload
+		wantErr error
+	}{
+		{
+			name: "valid",
+			code: valid,
+			keys: bothKeys,
+			want: &payload,
+		},
+		{
+			name: "retired key still verifies after rotation",
+			code: signedWithOld,
+			keys: bothKeys,
+			want: &oldPayload,
+		},
+		{
+			name:    "retired key dropped from the key set",
+			code:    signedWithOld,
+			keys:    map[string]ed25519.PublicKey{newID: newPub},
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "wrong key under the same key id",
+			code:    valid,
+			keys:    map[string]ed25519.PublicKey{newID: otherPub},
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "signed by a key that is not trusted",
+			code:    mustSign(t, Payload{TicketID: "t-1", EventID: "e-1", HolderID: "h-1", KeyID: newID}, otherPriv),
+			keys:    bothKeys,
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "tampered holder",
+			code:    reencode(t, valid, func(p *Payload) { p.HolderID = "h-2" }),
+			keys:    bothKeys,
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "tampered ticket",
+			code:    reencode(t, valid, func(p *Payload) { p.TicketID = "t-2" }),
+			keys:    bothKeys,
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "key id swapped to another trusted key",
+			code:    reencode(t, valid, func(p *Payload) { p.KeyID = oldID }),
+			keys:    bothKeys,
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "truncated signature",
+			code:    valid[:len(valid)-4],
+			keys:    bothKeys,
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "bare ticket id",
+			code:    "6f1c2b9e-2d8f-4c55-9a54-3f1f4f0c8f21",
+			keys:    bothKeys,
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "wrong prefix",
+			code:    "ZQR0" + strings.TrimPrefix(valid, prefix),
+			keys:    bothKeys,
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "bad base64",
+			code:    prefix + ".!!!." + strings.Split(valid, ".")[2],
+			keys:    bothKeys,
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "extra segment",
+			code:    valid + ".x",
+			keys:    bothKeys,
+			wantErr: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Verify(tt.code, tt.keys)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("Verify() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSigned(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "ZQR1.abc.def", want: true},
+		{code: "ZQR1", want: false},
+		{code: "6f1c2b9e-2d8f-4c55-9a54-3f1f4f0c8f21", want: false},
+		{code: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsSigned(tt.code); got != tt.want {
+			t.Errorf("IsSigned(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestSealPrivateKey(t *testing.T) {
+	_, _, priv := mustKey(t, time.Now())
+
+	sealed, err := SealPrivateKey("secret", priv)
+	if err != nil {
+		t.Fatalf("SealPrivateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		sealed  string
+		wantErr bool
+	}{
+		{name: "same secret", secret: "secret", sealed: sealed},
+		{name: "wrong secret", secret: "other", sealed: sealed, wantErr: true},
+		{name: "empty secret", secret: "", sealed: sealed, wantErr: true},
+		{name: "garbage", secret: "secret", sealed: "not-base64", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OpenPrivateKey(tt.secret, tt.sealed)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("OpenPrivateKey() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenPrivateKey() error = %v", err)
+			}
+			if !got.Equal(priv) {
+				t.Error("OpenPrivateKey() returned a different key")
+			}
+		})
+	}
+
+	if _, err := SealPrivateKey("", priv); err == nil {
+		t.Error("SealPrivateKey() with empty secret error = nil, want error")
+	}
+}
+
+func TestVerifyBytes(t *testing.T) {
+	_, pub, priv := mustKey(t, time.Now())
+	_, otherPub, _ := mustKey(t, time.Now())
+
+	data := []byte(`{"event":"e-1"}`)
+	signature := SignBytes(data, priv)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		signature string
+		key       ed25519.PublicKey
+		want      bool
+	}{
+		{name: "valid", data: data, signature: signature, key: pub, want: true},
+		{name: "tampered data", data: []byte(`{"event":"e-2"}`), signature: signature, key: pub},
+		{name: "wrong key", data: data, signature: signature, key: otherPub},
+		{name: "bad signature", data: data, signature: "%%", key: pub},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyBytes(tt.data, tt.signature, tt.key); got != tt.want {
+				t.Errorf("VerifyBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
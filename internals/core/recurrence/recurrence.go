@@ -0,0 +1,112 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	Weekly  = "weekly"
+	Monthly = "monthly"
+	Custom  = "custom"
+
+	// MaxOccurrences caps how many events a single series can generate.
+	MaxOccurrences = 104
+)
+
+type Rule struct {
+	Frequency string
+	Interval  int
+	Start     time.Time
+	Until     time.Time
+	Count     int
+	Dates     []time.Time
+	Exclude   []time.Time
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Occurrences expands the rule into sorted, de-duplicated calendar days.
+// Monthly rules skip months that do not contain the start day, so a series
+// starting on the 31st only runs in 31-day months. Excluded days do not
+// count toward Count, so a series always gets Count occurrences.
+func (r Rule) Occurrences() ([]time.Time, error) {
+	excluded := make(map[time.Time]bool)
+	for _, d := range r.Exclude {
+		excluded[day(d)] = true
+	}
+
+	var dates []time.Time
+	switch r.Frequency {
+	case Custom:
+		if len(r.Dates) == 0 {
+			return nil, errors.New("custom series need at least one date")
+		}
+		for _, d := range r.Dates {
+			dates = append(dates, day(d))
+		}
+
+	case Weekly, Monthly:
+		if r.Start.IsZero() {
+			return nil, errors.New("start date is required")
+		}
+		if r.Count <= 0 && r.Until.IsZero() {
+			return nil, errors.New("either an end date or an occurrence count is required")
+		}
+
+		interval := r.Interval
+		if interval <= 0 {
+			interval = 1
+		}
+
+		start := day(r.Start)
+		until := day(r.Until)
+		for i := 0; len(dates) < MaxOccurrences+1; i++ {
+			var next time.Time
+			if r.Frequency == Weekly {
+				next = start.AddDate(0, 0, 7*interval*i)
+			} else {
+				next = start.AddDate(0, interval*i, 0)
+				if next.Day() != start.Day() {
+					continue
+				}
+			}
+
+			if !r.Until.IsZero() && next.After(until) {
+				break
+			}
+			if r.Count > 0 && len(dates) == r.Count {
+				break
+			}
+			if excluded[next] {
+				continue
+			}
+			dates = append(dates, next)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported frequency %q", r.Frequency)
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var result []time.Time
+	for i, d := range dates {
+		if excluded[d] || (i > 0 && d.Equal(dates[i-1])) {
+			continue
+		}
+		result = append(result, d)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("rule does not produce any dates")
+	}
+	if len(result) > MaxOccurrences {
+		return nil, fmt.Errorf("series cannot have more than %d occurrences", MaxOccurrences)
+	}
+	return result, nil
+}
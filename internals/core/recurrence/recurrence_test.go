@@ -0,0 +1,121 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		want    []time.Time
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "weekly with count",
+			rule: Rule{Frequency: Weekly, Start: date(2025, 5, 5), Count: 3},
+			want: []time.Time{date(2025, 5, 5), date(2025, 5, 12), date(2025, 5, 19)},
+		},
+		{
+			name: "fortnightly until",
+			rule: Rule{Frequency: Weekly, Interval: 2, Start: date(2025, 5, 5), Until: date(2025, 6, 2)},
+			want: []time.Time{date(2025, 5, 5), date(2025, 5, 19), date(2025, 6, 2)},
+		},
+		{
+			name: "monthly on the 31st skips short months",
+			rule: Rule{Frequency: Monthly, Start: date(2025, 1, 31), Count: 4},
+			want: []time.Time{date(2025, 1, 31), date(2025, 3, 31), date(2025, 5, 31), date(2025, 7, 31)},
+		},
+		{
+			name: "monthly on the 29th skips february outside leap years",
+			rule: Rule{Frequency: Monthly, Start: date(2025, 1, 29), Until: date(2025, 4, 30)},
+			want: []time.Time{date(2025, 1, 29), date(2025, 3, 29), date(2025, 4, 29)},
+		},
+		{
+			name: "monthly on the 29th keeps february 29 in leap years",
+			rule: Rule{Frequency: Monthly, Start: date(2024, 1, 29), Count: 3},
+			want: []time.Time{date(2024, 1, 29), date(2024, 2, 29), date(2024, 3, 29)},
+		},
+		{
+			name: "excluded days do not count toward count",
+			rule: Rule{Frequency: Weekly, Start: date(2025, 5, 5), Count: 3, Exclude: []time.Time{date(2025, 5, 12)}},
+			want: []time.Time{date(2025, 5, 5), date(2025, 5, 19), date(2025, 5, 26)},
+		},
+		{
+			name: "excluded days shorten an until rule",
+			rule: Rule{Frequency: Weekly, Start: date(2025, 5, 5), Until: date(2025, 5, 19), Exclude: []time.Time{date(2025, 5, 12)}},
+			want: []time.Time{date(2025, 5, 5), date(2025, 5, 19)},
+		},
+		{
+			name: "custom dates are sorted and de-duplicated",
+			rule: Rule{Frequency: Custom, Dates: []time.Time{date(2025, 6, 1), date(2025, 5, 1), date(2025, 6, 1).Add(5 * time.Hour)}},
+			want: []time.Time{date(2025, 5, 1), date(2025, 6, 1)},
+		},
+		{
+			name:    "count at the cap",
+			rule:    Rule{Frequency: Weekly, Start: date(2025, 1, 6), Count: MaxOccurrences},
+			wantLen: MaxOccurrences,
+		},
+		{
+			name:    "count over the cap",
+			rule:    Rule{Frequency: Weekly, Start: date(2025, 1, 6), Count: MaxOccurrences + 1},
+			wantErr: true,
+		},
+		{
+			name:    "until beyond the cap",
+			rule:    Rule{Frequency: Weekly, Start: date(2025, 1, 6), Until: date(2028, 1, 6)},
+			wantErr: true,
+		},
+		{
+			name:    "every date excluded",
+			rule:    Rule{Frequency: Custom, Dates: []time.Time{date(2025, 5, 1)}, Exclude: []time.Time{date(2025, 5, 1)}},
+			wantErr: true,
+		},
+		{
+			name:    "no end",
+			rule:    Rule{Frequency: Weekly, Start: date(2025, 5, 5)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown frequency",
+			rule:    Rule{Frequency: "daily", Start: date(2025, 5, 5), Count: 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.Occurrences()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Occurrences() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Occurrences() error = %v", err)
+			}
+
+			if tt.want == nil {
+				if len(got) != tt.wantLen {
+					t.Fatalf("Occurrences() returned %d dates, want %d", len(got), tt.wantLen)
+				}
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ClientStorage struct {
@@ -29,10 +31,9 @@ type ClientRepository interface {
 	AddReviewRatingsOfClient(ctx context.Context, newReviewRatings *clientModel.Review) error
 	CreateAdminWalletTransaction(ctx context.Context, newAdminWalletTransaction *adminModel.AdminWalletTransaction) error
 	CreateBooking(ctx context.Context, booking *adminModel.Booking) error
-	CreateEvent(ctx context.Context, event *clientModel.Event) error
-	CreateEventDetails(ctx context.Context, eventDetails *clientModel.EventDetails) error
-	CreateLocation(ctx context.Context, location *clientModel.Location) error
+	CreateEvent(ctx context.Context, event *clientModel.Event, eventDetails *clientModel.EventDetails, tiers []clientModel.TicketTier) error
 	CreateTransaction(ctx context.Context, newTransaction *clientModel.Transaction) error
+	RecordRefundedPayment(ctx context.Context, adminEmail string, payment *clientModel.Transaction, purpose string) (bool, error)
 	CreditAdminWallet(amount float64, email string) error
 	CreditAmountToAdminWallet(ctx context.Context, amount float64, adminEmail string) error
 	DeleteReview(ctx context.Context, reviewID string) error
@@ -48,6 +49,7 @@ type ClientRepository interface {
 	GetUpcomingEvents(ctx context.Context) ([]clientModel.Event, []clientModel.EventDetails, error)
 	GetUserByID(ctx context.Context, clientID string) (*models.User, error)
 	GetUserDetailsByID(ctx context.Context, clientID string) (*models.UserDetails, error)
+	GetUserDetailsByIDs(ctx context.Context, userIDs []uuid.UUID) ([]models.UserDetails, error)
 	GetVendorAverageRating(ctx context.Context, vendorID string) (float64, error)
 	GetVendorCategories(ctx context.Context, vendorID string) ([]vendorModel.Category, error)
 	GetVendorDetailsByID(ctx context.Context, vendorID string) (*models.UserDetails, error)
@@ -61,8 +63,8 @@ type ClientRepository interface {
 	IsVendorServiceAvailable(ctx context.Context, vendorID, service string) (bool, error)
 	MakeMasterOfCeremony(ctx context.Context, userID string) error
 	ServiceExists(ctx context.Context, vendorID, serviceID string) (bool, error)
-	UpdateEvent(ctx context.Context, event *clientModel.Event) error
-	UpdateEventDetails(ctx context.Context, details *clientModel.EventDetails) error
+	UpdateEventFields(ctx context.Context, eventID string, eventFields, detailFields map[string]interface{}) error
+	UpdateEvents(ctx context.Context, updates []EventUpdate) error
 	UpdateMasterOfCeremonyStatus(clientID string, status bool) error
 	UpdatePassword(ctx context.Context, clientID, hashedPassword string) error
 	UpdateReviewRatingsOfClient(ctx context.Context, reviewID, review string, rating float64) error
@@ -73,18 +75,167 @@ type ClientRepository interface {
 	VerifyPassword(hashedPassword, password string) bool
 	ReleasePaymentToVendor(ctx context.Context, vendorID string, price float64) error
 	MarkBookingAsConfirmedAndReleased(ctx context.Context, bookingID string) error
+	ReleaseBookingFunds(ctx context.Context, bookingID string) (bool, error)
 	EventExists(ctx context.Context, eventID string) (bool, error)
 	GetEventAmount(ctx context.Context, eventID string) (float64, error)
 	CreateTicket(ctx context.Context, ticket *clientModel.Ticket) error
 	CreateQRCode(ctx context.Context, qr *clientModel.QR) error
 	RefundAmount(ctx context.Context, adminEmail string, clientID string, amount int) error
-	GetBookingCount(ctx context.Context, clientID string) (int, error)
-	UpdateTicket(ctx context.Context, eventID, status string) error
+	CountBookingsSince(ctx context.Context, clientID string, since time.Time) (int, error)
+	GetTicketByTicketID(ctx context.Context, ticketID string) (*clientModel.Ticket, error)
+	GetActiveClientEventTickets(ctx context.Context, clientID, eventID string) ([]clientModel.Ticket, error)
 	GetTicketsByClientID(ctx context.Context, clientID string) ([]clientModel.Ticket, error)
 	GetEventNameByID(ctx context.Context, eventID string) (string, error)
 	GetTicketsByEventID(ctx context.Context, eventID string) ([]clientModel.Ticket, error)
 	GetEventPrice(ctx context.Context, eventID string) (float64, error)
 	CreateFundRelease(ctx context.Context, req *adminModel.FundRelease) error
+	CreateQuoteRequest(ctx context.Context, quoteRequest *clientModel.QuoteRequest) error
+	GetQuoteRequestByID(ctx context.Context, quoteRequestID string) (*clientModel.QuoteRequest, error)
+	GetQuoteRequestsByUserID(ctx context.Context, userID string) ([]clientModel.QuoteRequest, error)
+	CreateQuotes(ctx context.Context, quoteRequestID string, quotes []clientModel.Quote) error
+	GetQuoteByID(ctx context.Context, quoteID string) (*clientModel.Quote, error)
+	AcceptQuote(ctx context.Context, adminEmail string, payment *clientModel.Transaction, quoteID string, booking *adminModel.Booking) (bool, bool, error)
+	GetBookingsAwaitingRelease(ctx context.Context, before time.Time) ([]adminModel.Booking, error)
+	GetReleaseGraceDays(ctx context.Context, bookingID string) (int, error)
+	CreateBookingCategory(ctx context.Context, bookingCategory *clientModel.BookingCategory) error
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+	SetReleasePolicy(ctx context.Context, policy *clientModel.ReleasePolicy) error
+	CreateNotification(ctx context.Context, notification *clientModel.Notification) error
+	CreateDispute(ctx context.Context, dispute *clientModel.Dispute) error
+	GetDisputeByID(ctx context.Context, disputeID string) (*clientModel.Dispute, error)
+	HasOpenDispute(ctx context.Context, bookingID string) (bool, error)
+	AddDisputeResponse(ctx context.Context, disputeID, response string, attachments []clientModel.DisputeAttachment) error
+	SettleDispute(ctx context.Context, adminEmail string, dispute *clientModel.Dispute, refundAmount, releaseAmount int, bookingStatus string) (bool, error)
+	CreateBookingDeadline(ctx context.Context, deadline *clientModel.BookingDeadline) error
+	GetBookingDeadline(ctx context.Context, bookingID string) (*clientModel.BookingDeadline, error)
+	MarkBookingResponded(ctx context.Context, bookingID string) error
+	GetExpiredBookingDeadlines(ctx context.Context, now time.Time) ([]clientModel.BookingDeadline, error)
+	CancelBookingWithRefund(ctx context.Context, adminEmail string, booking *adminModel.Booking, bookingStatus, purpose string) (bool, error)
+	ConfirmPendingBooking(ctx context.Context, bookingID string) (bool, error)
+	IsVendorSlotBooked(ctx context.Context, vendorID string, date time.Time) (bool, error)
+	AddCartItem(ctx context.Context, item *clientModel.CartItem) error
+	CartItemExists(ctx context.Context, clientID, serviceID string) (bool, error)
+	GetCartItems(ctx context.Context, clientID string) ([]resonses.CartItemDetails, error)
+	DeleteCartItem(ctx context.Context, clientID, cartItemID string) error
+	RemoveOrderedCartItems(ctx context.Context, clientID string, items []clientModel.OrderItem) error
+	CreateOrder(ctx context.Context, order *clientModel.Order) error
+	GetOrderByID(ctx context.Context, orderID string) (*clientModel.Order, error)
+	ClaimOrderPayment(ctx context.Context, adminEmail, orderID string, payment *clientModel.Transaction) (bool, error)
+	UpdateOrderItem(ctx context.Context, item *clientModel.OrderItem) error
+	UpdateOrderStatus(ctx context.Context, orderID, status string) error
+	RefundToClientWallet(ctx context.Context, adminEmail string, clientID uuid.UUID, amount int, purpose string) error
+	GetBookingPolicies(ctx context.Context) ([]clientModel.BookingPolicy, error)
+	SetBookingPolicy(ctx context.Context, policy *clientModel.BookingPolicy) error
+	CountPendingBookings(ctx context.Context, clientID string) (int, error)
+	CountTicketsSince(ctx context.Context, clientID string, since time.Time) (int, error)
+	CountClientEventTickets(ctx context.Context, clientID, eventID string) (int, error)
+	GetLastCancellationTime(ctx context.Context, clientID string, purposes []string) (*time.Time, error)
+	GetEventByID(ctx context.Context, eventID string) (*clientModel.Event, error)
+	UpdateEventStatus(ctx context.Context, eventID, status string) error
+	CancelEventWithCancellation(ctx context.Context, cancellation *clientModel.EventCancellation) (bool, error)
+	GetEventCancellationsInProgress(ctx context.Context) ([]clientModel.EventCancellation, error)
+	ClaimEventCancellation(ctx context.Context, eventID uuid.UUID, until time.Time) (bool, error)
+	UpdateEventCancellationProgress(ctx context.Context, cancellation *clientModel.EventCancellation) error
+	GetRefundableTickets(ctx context.Context, eventID string, skip []string, limit int) ([]clientModel.Ticket, error)
+	ClaimTicketRefund(ctx context.Context, ticket *clientModel.Ticket) (bool, error)
+	ReleaseTicketRefund(ctx context.Context, ticket *clientModel.Ticket, ticketStatus string) error
+	RefundTicket(ctx context.Context, adminEmail string, ticket *clientModel.Ticket, amount int, fromStatus, ticketStatus, method, purpose string) (bool, error)
+	GetTicketTiersByEventIDs(ctx context.Context, eventIDs []uuid.UUID) ([]clientModel.TicketTier, error)
+	GetTicketTierByID(ctx context.Context, tierID string) (*clientModel.TicketTier, error)
+	SaveTicketTiers(ctx context.Context, eventID uuid.UUID, tiers []clientModel.TicketTier, removeIDs []uuid.UUID) error
+	IssueEventTickets(ctx context.Context, adminEmail string, payment *clientModel.Transaction, eventID uuid.UUID, tierID *uuid.UUID, tickets []clientModel.Ticket, qrs []clientModel.QR) (bool, bool, error)
+	GetQRByTicketID(ctx context.Context, ticketID uuid.UUID) (*clientModel.QR, error)
+	MarkTicketScanned(ctx context.Context, ticketID, scannerID uuid.UUID, gate string, scannedAt time.Time) (bool, error)
+	MergeOfflineScan(ctx context.Context, ticketID, scannerID uuid.UUID, gate string, scannedAt time.Time) (bool, error)
+	IsTicketCheckedIn(ctx context.Context, ticketID uuid.UUID) (bool, error)
+	UndoTicketScan(ctx context.Context, ticketID uuid.UUID) (bool, error)
+	CountEventCheckIns(ctx context.Context, eventID string) (int, int, error)
+	GetActiveQRSigningKey(ctx context.Context) (*clientModel.QRSigningKey, error)
+	GetQRSigningKeys(ctx context.Context) ([]clientModel.QRSigningKey, error)
+	RotateQRSigningKey(ctx context.Context, key *clientModel.QRSigningKey) error
+	GetQRByPayload(ctx context.Context, payload string) (*clientModel.QR, error)
+	ReplaceTicketQR(ctx context.Context, ticketID uuid.UUID, qr *clientModel.QR) error
+	GetTicketsWithUnsignedQR(ctx context.Context, limit int) ([]clientModel.Ticket, error)
+	GetEventTicketHolders(ctx context.Context, eventID string) ([]resonses.EventTicketHolder, error)
+	ScanRecordExists(ctx context.Context, ticketID uuid.UUID, deviceID string, scannedAt time.Time) (bool, error)
+	CreateScanRecord(ctx context.Context, record *clientModel.ScanRecord) error
+	GetEventDetailsByEventID(ctx context.Context, eventID string) (*clientModel.EventDetails, error)
+	CreateTicketTransfer(ctx context.Context, transfer *clientModel.TicketTransfer) error
+	GetTicketTransferByID(ctx context.Context, transferID string) (*clientModel.TicketTransfer, error)
+	HasPendingTicketTransfer(ctx context.Context, ticketID uuid.UUID) (bool, error)
+	GetTicketTransfersByClientID(ctx context.Context, clientID string) ([]clientModel.TicketTransfer, error)
+	UpdateTicketTransferStatus(ctx context.Context, transferID uuid.UUID, fromStatus, toStatus string) (bool, error)
+	AcceptTicketTransfer(ctx context.Context, transfer *clientModel.TicketTransfer, qr *clientModel.QR) (bool, error)
+	SearchEvents(ctx context.Context, filter EventSearchFilter) ([]clientModel.Event, []clientModel.EventDetails, error)
+	GetEventsNearby(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]resonses.EventDistance, error)
+	GetEventsByIDs(ctx context.Context, eventIDs []uuid.UUID) ([]clientModel.Event, []clientModel.EventDetails, error)
+	GetEventAttendees(ctx context.Context, eventID string, filter AttendeeFilter) ([]resonses.EventTicketHolder, int, error)
+	GetEventSalesTotals(ctx context.Context, eventID string, from, to time.Time) (*resonses.EventSalesTotals, error)
+	GetEventDailySales(ctx context.Context, eventID string, from, to time.Time, timezone string) ([]resonses.DailySales, error)
+	GetEventRefundTotal(ctx context.Context, eventID string, from, to time.Time) (int, int, error)
+	GetEventTierSales(ctx context.Context, eventID string, from, to time.Time) ([]resonses.TierSales, error)
+	GetEventTicketHolderIDs(ctx context.Context, eventID string) ([]uuid.UUID, error)
+	PublishDueEvents(ctx context.Context, now time.Time) (int64, error)
+	ArchiveEventsBefore(ctx context.Context, before time.Time) (int64, error)
+	CreateEventSeries(ctx context.Context, series *clientModel.EventSeries, events []clientModel.Event, details []clientModel.EventDetails, tiers []clientModel.TicketTier) error
+	GetEventSeriesByID(ctx context.Context, seriesID string) (*clientModel.EventSeries, error)
+	GetSeriesEvents(ctx context.Context, seriesID string, from time.Time, statuses []string) ([]clientModel.Event, []clientModel.EventDetails, error)
+	CountAssetReferences(ctx context.Context, publicID string) (int64, error)
+	GetEventSettlement(ctx context.Context, eventID string) (*clientModel.EventSettlement, error)
+	CreateEventSettlement(ctx context.Context, settlement *clientModel.EventSettlement, fundRelease *adminModel.FundRelease) error
+	GetEventMember(ctx context.Context, eventID, userID string) (*clientModel.EventMember, error)
+	SaveEventMember(ctx context.Context, member *clientModel.EventMember) error
+	GetEventMembers(ctx context.Context, eventID string) ([]clientModel.EventMember, error)
+	CreateEventAuditLog(ctx context.Context, entry *clientModel.EventAuditLog) error
+	GetEventAuditLogs(ctx context.Context, eventID string, limit, offset int) ([]clientModel.EventAuditLog, int64, error)
+	CreateEventMedia(ctx context.Context, media *clientModel.EventMedia) error
+	GetEventMedia(ctx context.Context, eventID string) ([]clientModel.EventMedia, error)
+	GetEventMediaByID(ctx context.Context, eventID, mediaID string) (*clientModel.EventMedia, error)
+	UpdateEventMediaCaption(ctx context.Context, mediaID uuid.UUID, caption string) error
+	SetEventCover(ctx context.Context, eventID uuid.UUID, media *clientModel.EventMedia) error
+	ReorderEventMedia(ctx context.Context, eventID uuid.UUID, mediaIDs []uuid.UUID) error
+	DeleteEventMedia(ctx context.Context, media *clientModel.EventMedia) error
+	CreateVenue(ctx context.Context, venue *clientModel.Venue) error
+	GetVenueByID(ctx context.Context, venueID string) (*clientModel.Venue, error)
+	GetVenuesByOwner(ctx context.Context, ownerID string) ([]clientModel.Venue, error)
+	UpdateVenue(ctx context.Context, venue *clientModel.Venue, moveEvents bool) error
+	GetVenueEvents(ctx context.Context, venueID string, statuses []string) ([]clientModel.Event, []clientModel.EventDetails, error)
+	GetVenueMaxTicketLimit(ctx context.Context, venueID string) (int, error)
+	CreateVenuePhotos(ctx context.Context, photos []clientModel.VenuePhoto) error
+	DeleteVenuePhoto(ctx context.Context, venueID, photoID string) (*clientModel.VenuePhoto, error)
+}
+
+type EventUpdate struct {
+	EventID      uuid.UUID
+	EventFields  map[string]interface{}
+	DetailFields map[string]interface{}
+	Tier         *clientModel.TicketTier
+}
+
+type AttendeeFilter struct {
+	Status    string
+	CheckedIn *bool
+	Offset    int
+	Limit     int
+	After     *resonses.EventTicketHolder
+}
+
+type EventSearchFilter struct {
+	Query         string
+	City          string
+	Country       string
+	DateFrom      *time.Time
+	DateTo        *time.Time
+	MinPrice      int
+	MaxPrice      int
+	FreeOnly      bool
+	OnlyAvailable bool
+	SortBy        string
+	Limit         int
+
+	AfterEventID *uuid.UUID
+	AfterDate    time.Time
+	AfterNumber  int
 }
 
 func NewClientRepository(db *gorm.DB) ClientRepository {
@@ -134,32 +285,27 @@ func (r *ClientStorage) GetCategories(ctx context.Context) ([]vendorModel.Catego
 	return categories, nil
 }
 
-func (r *ClientStorage) CreateEvent(ctx context.Context, event *clientModel.Event) error {
-	err := r.DB.WithContext(ctx).Create(&event).Error
-	if err != nil {
+func (r *ClientStorage) CreateEvent(ctx context.Context, event *clientModel.Event, eventDetails *clientModel.EventDetails, tiers []clientModel.TicketTier) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Create(event).Error; err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	return nil
-
-}
-
-func (r *ClientStorage) CreateEventDetails(ctx context.Context, eventDetails *clientModel.EventDetails) error {
-	err := r.DB.WithContext(ctx).Create(&eventDetails).Error
-	if err != nil {
+	if err := tx.Create(eventDetails).Error; err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	return nil
-}
-
-func (r *ClientStorage) CreateLocation(ctx context.Context, location *clientModel.Location) error {
-	err := r.DB.WithContext(ctx).Create(&location).Error
-	if err != nil {
-		return err
+	if len(tiers) > 0 {
+		if err := tx.Create(&tiers).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
-	return nil
+	return tx.Commit().Error
 }
 
 func (r *ClientStorage) IsMaterofCeremony(ctx context.Context, clientID string) (bool, error) {
@@ -172,14 +318,59 @@ func (r *ClientStorage) IsMaterofCeremony(ctx context.Context, clientID string)
 
 }
 
-func (r *ClientStorage) UpdateEvent(ctx context.Context, event *clientModel.Event) error {
-	result := r.DB.Model(&event).Where("event_id = ?", event.EventID).Updates(&event)
-	return result.Error
+func (r *ClientStorage) UpdateEventFields(ctx context.Context, eventID string, eventFields, detailFields map[string]interface{}) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if len(eventFields) > 0 {
+		if err := tx.Model(&clientModel.Event{}).Where("event_id = ?", eventID).Updates(eventFields).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if len(detailFields) > 0 {
+		if err := tx.Model(&clientModel.EventDetails{}).Where("event_id = ?", eventID).Updates(detailFields).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
 }
 
-func (r *ClientStorage) UpdateEventDetails(ctx context.Context, details *clientModel.EventDetails) error {
-	result := r.DB.Model(&details).Where("event_id = ?", details.EventID).Updates(&details)
-	return result.Error
+// UpdateEvents applies edits to several events, such as the rest of a
+// series, so either every occurrence changes or none do.
+func (r *ClientStorage) UpdateEvents(ctx context.Context, updates []EventUpdate) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	for _, update := range updates {
+		if len(update.EventFields) > 0 {
+			if err := tx.Model(&clientModel.Event{}).Where("event_id = ?", update.EventID).Updates(update.EventFields).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		if len(update.DetailFields) > 0 {
+			if err := tx.Model(&clientModel.EventDetails{}).Where("event_id = ?", update.EventID).Updates(update.DetailFields).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		if update.Tier != nil {
+			if err := updateTicketTier(tx, update.EventID, *update.Tier); err != nil {
+				tx.Rollback()
+				return err
+			}
+			if err := syncEventTicketSummary(tx, update.EventID); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	return tx.Commit().Error
 }
 
 func (r *ClientStorage) GetUserDetailsByID(ctx context.Context, clientID string) (*models.UserDetails, error) {
@@ -194,6 +385,19 @@ func (r *ClientStorage) GetUserDetailsByID(ctx context.Context, clientID string)
 	return &userDetails, nil
 }
 
+func (r *ClientStorage) GetUserDetailsByIDs(ctx context.Context, userIDs []uuid.UUID) ([]models.UserDetails, error) {
+	var userDetails []models.UserDetails
+	if len(userIDs) == 0 {
+		return userDetails, nil
+	}
+
+	err := r.DB.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&userDetails).Error
+	if err != nil {
+		return nil, err
+	}
+	return userDetails, nil
+}
+
 func (r *ClientStorage) UpdateUserDetails(ctx context.Context, userDetails *models.UserDetails) error {
 	err := r.DB.WithContext(ctx).
 		Model(&models.UserDetails{}).
@@ -235,7 +439,7 @@ func (r *ClientStorage) UpdatePassword(ctx context.Context, clientID, hashedPass
 func (r *ClientStorage) GetUpcomingEvents(ctx context.Context) ([]clientModel.Event, []clientModel.EventDetails, error) {
 	var events []clientModel.Event
 	err := r.DB.WithContext(ctx).
-		Where("date >= ?", time.Now()).
+		Where("ends_at >= ? AND status = ?", time.Now(), "published").
 		Find(&events).Error
 	if err != nil {
 		return nil, nil, err
@@ -439,6 +643,82 @@ func (r *ClientStorage) CreateTransaction(ctx context.Context, newTransaction *c
 
 }
 
+// lockPaymentIntent holds a lock on the payment intent until the transaction
+// ends, so webhook retries for the same checkout run one after the other, and
+// reports whether the payment has already been recorded.
+func lockPaymentIntent(tx *gorm.DB, paymentIntentID string) (bool, error) {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", paymentIntentID).Error; err != nil {
+		return false, fmt.Errorf("failed to lock payment: %w", err)
+	}
+
+	var count int64
+	err := tx.Model(&clientModel.Transaction{}).
+		Where("payment_intent_id = ? AND payment_status = ?", paymentIntentID, "paid").
+		Count(&count).Error
+	return count > 0, err
+}
+
+// recordStripePayment stores a completed checkout and credits it to the admin
+// wallet, where it is held until the booking is released or refunded.
+func recordStripePayment(tx *gorm.DB, adminEmail string, payment *clientModel.Transaction) error {
+	if err := tx.Create(payment).Error; err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	err := tx.Model(&adminModel.AdminWallet{}).
+		Where("email = ?", adminEmail).
+		Updates(map[string]interface{}{
+			"balance":        gorm.Expr("balance + ?", payment.AmountPaid),
+			"total_deposits": gorm.Expr("total_deposits + ?", payment.AmountPaid),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to credit admin wallet: %w", err)
+	}
+
+	adminTransaction := adminModel.AdminWalletTransaction{
+		Date:   time.Now(),
+		Type:   payment.Purpose,
+		Amount: float64(payment.AmountPaid),
+		Status: "succeeded",
+	}
+	if err := tx.Create(&adminTransaction).Error; err != nil {
+		return fmt.Errorf("failed to create admin wallet transaction: %w", err)
+	}
+	return nil
+}
+
+// RecordRefundedPayment records a checkout that cannot be fulfilled and
+// refunds it to the client's wallet in the same transaction. It reports false
+// when the payment was already handled by an earlier delivery.
+func (r *ClientStorage) RecordRefundedPayment(ctx context.Context, adminEmail string, payment *clientModel.Transaction, purpose string) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	recorded, err := lockPaymentIntent(tx, payment.PaymentIntentID)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if recorded {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := recordStripePayment(tx, adminEmail, payment); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := refundToClientWallet(tx, adminEmail, payment.UserID, payment.AmountPaid, purpose); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *ClientStorage) MakeMasterOfCeremony(ctx context.Context, userID string) error {
 	return r.DB.Model(&models.UserDetails{}).Where("user_id = ?", userID).Update("master_of_ceremonies", true).Error
 
@@ -624,7 +904,6 @@ func (r *ClientStorage) UpdateClientApprovalStatus(ctx context.Context, bookingI
 }
 
 func (r *ClientStorage) ReleasePaymentToVendor(ctx context.Context, vendorID string, price float64) error {
-	var vendorWallet vendorModel.Wallet
 	vendorUUID, err := uuid.Parse(vendorID)
 	if err != nil {
 		return fmt.Errorf("invalid vendor ID: %w", err)
@@ -632,7 +911,17 @@ func (r *ClientStorage) ReleasePaymentToVendor(ctx context.Context, vendorID str
 
 	tx := r.DB.WithContext(ctx).Begin()
 
-	err = tx.Where("vendor_id = ?", vendorUUID).First(&vendorWallet).Error
+	if err := creditVendorFromAdminWallet(tx, vendorUUID, price); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func creditVendorFromAdminWallet(tx *gorm.DB, vendorUUID uuid.UUID, price float64) error {
+	var vendorWallet vendorModel.Wallet
+	err := tx.Where("vendor_id = ?", vendorUUID).First(&vendorWallet).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		vendorWallet = vendorModel.Wallet{
 			VendorID:         vendorUUID,
@@ -641,11 +930,9 @@ func (r *ClientStorage) ReleasePaymentToVendor(ctx context.Context, vendorID str
 			TotalWithdrawals: 0,
 		}
 		if err := tx.Create(&vendorWallet).Error; err != nil {
-			tx.Rollback()
 			return fmt.Errorf("failed to create vendor wallet: %w", err)
 		}
 	} else if err != nil {
-		tx.Rollback()
 		return fmt.Errorf("failed to fetch vendor wallet: %w", err)
 	}
 
@@ -653,22 +940,18 @@ func (r *ClientStorage) ReleasePaymentToVendor(ctx context.Context, vendorID str
 	vendorWallet.TotalDeposits += int64(price)
 
 	if err := tx.Save(&vendorWallet).Error; err != nil {
-		tx.Rollback()
 		return fmt.Errorf("failed to update vendor wallet: %w", err)
 	}
 
 	var adminWallet adminModel.AdminWallet
 	err = tx.Where("email = ?", "admin@example.com").First(&adminWallet).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		tx.Rollback()
 		return fmt.Errorf("admin wallet not found")
 	} else if err != nil {
-		tx.Rollback()
 		return err
 	}
 
 	if adminWallet.Balance < price {
-		tx.Rollback()
 		return fmt.Errorf("admin wallet does not have enough balance")
 	}
 
@@ -679,7 +962,6 @@ func (r *ClientStorage) ReleasePaymentToVendor(ctx context.Context, vendorID str
 		"wallet_balance": vendorWallet.WalletBalance,
 		"total_deposits": vendorWallet.TotalDeposits,
 	}).Error; err != nil {
-		tx.Rollback()
 		return fmt.Errorf("failed to update vendor wallet: %w", err)
 	}
 
@@ -692,7 +974,6 @@ func (r *ClientStorage) ReleasePaymentToVendor(ctx context.Context, vendorID str
 		DateOfPayment: time.Now(),
 	}
 	if err := tx.Create(&vendorTransaction).Error; err != nil {
-		tx.Rollback()
 		return err
 	}
 
@@ -703,11 +984,53 @@ func (r *ClientStorage) ReleasePaymentToVendor(ctx context.Context, vendorID str
 		Status: "withdrawn",
 	}
 	if err := tx.Create(&adminTransaction).Error; err != nil {
-		tx.Rollback()
 		return err
 	}
 
-	return tx.Commit().Error
+	return nil
+}
+
+// ReleaseBookingFunds marks the booking completed and pays the vendor in one
+// transaction. It reports false when the funds were already released or the
+// booking has an open dispute, in which case nothing is paid.
+func (r *ClientStorage) ReleaseBookingFunds(ctx context.Context, bookingID string) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	var booking adminModel.Booking
+	if err := tx.Where("booking_id = ?", bookingID).First(&booking).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to find booking: %w", err)
+	}
+
+	result := tx.Model(&adminModel.Booking{}).
+		Where("booking_id = ? AND is_fund_released = ?", bookingID, false).
+		Where("NOT EXISTS (SELECT 1 FROM disputes d WHERE d.booking_id = bookings.booking_id AND d.status IN ?)", []string{"open", "responded"}).
+		Updates(map[string]interface{}{
+			"is_vendor_approved": true,
+			"is_client_approved": true,
+			"is_fund_released":   true,
+			"status":             "completed",
+			"updated_at":         time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update booking: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := creditVendorFromAdminWallet(tx, booking.VendorID, float64(booking.Price)); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (r *ClientStorage) MarkBookingAsConfirmedAndReleased(ctx context.Context, bookingID string) error {
@@ -822,10 +1145,10 @@ func (r *ClientStorage) UpdateBookingStatus(ctx context.Context, bookingID, stat
 	return r.DB.WithContext(ctx).Model(&adminModel.Booking{}).Where("booking_id = ?", bookingID).Update("status", status).Error
 }
 
-func (r *ClientStorage) GetBookingCount(ctx context.Context, clientID string) (int, error) {
+func (r *ClientStorage) CountBookingsSince(ctx context.Context, clientID string, since time.Time) (int, error) {
 	var count int64
 
-	err := r.DB.Model(&adminModel.Booking{}).Where("client_id = ? AND DATE(created_at) = CURRENT_DATE", clientID).Count(&count).Error
+	err := r.DB.WithContext(ctx).Model(&adminModel.Booking{}).Where("client_id = ? AND created_at >= ?", clientID, since).Count(&count).Error
 
 	if err != nil {
 		return 0, err
@@ -834,8 +1157,24 @@ func (r *ClientStorage) GetBookingCount(ctx context.Context, clientID string) (i
 	return int(count), nil
 }
 
-func (r *ClientStorage) UpdateTicket(ctx context.Context, eventID, status string) error {
-	return r.DB.Model(&clientModel.Ticket{}).Where("event_id = ?", eventID).Update("status", status).Error
+func (r *ClientStorage) GetTicketByTicketID(ctx context.Context, ticketID string) (*clientModel.Ticket, error) {
+	var ticket clientModel.Ticket
+	err := r.DB.WithContext(ctx).Where("ticket_id = ?", ticketID).First(&ticket).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *ClientStorage) GetActiveClientEventTickets(ctx context.Context, clientID, eventID string) ([]clientModel.Ticket, error) {
+	var tickets []clientModel.Ticket
+	err := r.DB.WithContext(ctx).
+		Where("client_id = ? AND event_id = ? AND status NOT IN ?", clientID, eventID, []string{"cancelled", "refunded"}).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
 }
 
 func (r *ClientStorage) GetTicketsByClientID(ctx context.Context, clientID string) ([]clientModel.Ticket, error) {
@@ -888,3 +1227,2329 @@ func (r *ClientStorage) GetEventPrice(ctx context.Context, eventID string) (floa
 func (r *ClientStorage) CreateFundRelease(ctx context.Context, req *adminModel.FundRelease) error {
 	return r.DB.WithContext(ctx).Create(&req).Error
 }
+
+func (r *ClientStorage) CreateQuoteRequest(ctx context.Context, quoteRequest *clientModel.QuoteRequest) error {
+	return r.DB.WithContext(ctx).Create(quoteRequest).Error
+}
+
+func (r *ClientStorage) GetQuoteRequestByID(ctx context.Context, quoteRequestID string) (*clientModel.QuoteRequest, error) {
+	var quoteRequest clientModel.QuoteRequest
+	err := r.DB.WithContext(ctx).
+		Preload("Quotes").
+		Where("quote_request_id = ?", quoteRequestID).
+		First(&quoteRequest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quoteRequest, nil
+}
+
+func (r *ClientStorage) GetQuoteRequestsByUserID(ctx context.Context, userID string) ([]clientModel.QuoteRequest, error) {
+	var quoteRequests []clientModel.QuoteRequest
+	err := r.DB.WithContext(ctx).
+		Preload("Quotes").
+		Where("client_id = ? OR vendor_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&quoteRequests).Error
+	if err != nil {
+		return nil, err
+	}
+	return quoteRequests, nil
+}
+
+func (r *ClientStorage) CreateQuotes(ctx context.Context, quoteRequestID string, quotes []clientModel.Quote) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Create(&quotes).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create quotes: %w", err)
+	}
+
+	if err := tx.Model(&clientModel.QuoteRequest{}).
+		Where("quote_request_id = ?", quoteRequestID).
+		Update("status", "quoted").Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update quote request status: %w", err)
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) GetQuoteByID(ctx context.Context, quoteID string) (*clientModel.Quote, error) {
+	var quote clientModel.Quote
+	err := r.DB.WithContext(ctx).Where("quote_id = ?", quoteID).First(&quote).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// AcceptQuote records the quote payment and books the quote in one
+// transaction keyed on the payment intent. The first result is false when an
+// earlier delivery already handled the payment. A quote that is no longer
+// offered is refunded to the client's wallet and reported as not accepted.
+func (r *ClientStorage) AcceptQuote(ctx context.Context, adminEmail string, payment *clientModel.Transaction, quoteID string, booking *adminModel.Booking) (bool, bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	recorded, err := lockPaymentIntent(tx, payment.PaymentIntentID)
+	if err != nil {
+		tx.Rollback()
+		return false, false, err
+	}
+	if recorded {
+		tx.Rollback()
+		return false, false, nil
+	}
+
+	if err := recordStripePayment(tx, adminEmail, payment); err != nil {
+		tx.Rollback()
+		return false, false, err
+	}
+
+	var quote clientModel.Quote
+	if err := tx.Where("quote_id = ?", quoteID).First(&quote).Error; err != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to find quote: %w", err)
+	}
+
+	result := tx.Model(&clientModel.Quote{}).
+		Where("quote_id = ? AND status = ?", quoteID, "offered").
+		Updates(map[string]interface{}{
+			"status":     "accepted",
+			"booking_id": booking.BookingID,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to accept quote: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		if err := refundToClientWallet(tx, adminEmail, payment.UserID, payment.AmountPaid, "Quote Booking Refund"); err != nil {
+			tx.Rollback()
+			return false, false, err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return false, false, err
+		}
+		return true, false, nil
+	}
+
+	if err := tx.Create(booking).Error; err != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	if err := tx.Model(&clientModel.Quote{}).
+		Where("quote_request_id = ? AND quote_id <> ? AND status = ?", quote.QuoteRequestID, quoteID, "offered").
+		Update("status", "declined").Error; err != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to decline remaining quotes: %w", err)
+	}
+
+	if err := tx.Model(&clientModel.QuoteRequest{}).
+		Where("quote_request_id = ?", quote.QuoteRequestID).
+		Update("status", "booked").Error; err != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to update quote request status: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, false, err
+	}
+	return true, true, nil
+}
+
+func (r *ClientStorage) GetBookingsAwaitingRelease(ctx context.Context, before time.Time) ([]adminModel.Booking, error) {
+	var bookings []adminModel.Booking
+	err := r.DB.WithContext(ctx).
+		Where("is_vendor_approved = ? AND is_client_approved = ? AND is_fund_released = ?", true, false, false).
+		Where("status NOT IN ?", []string{"cancelled", "rejected"}).
+		Where("date <= ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM disputes d WHERE d.booking_id = bookings.booking_id AND d.status IN ?)", []string{"open", "responded"}).
+		Find(&bookings).Error
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (r *ClientStorage) GetReleaseGraceDays(ctx context.Context, bookingID string) (int, error) {
+	var graceDays sql.NullInt64
+	err := r.DB.WithContext(ctx).
+		Table("release_policies").
+		Select("release_policies.grace_days").
+		Joins("JOIN booking_categories bc ON bc.category_id = release_policies.category_id").
+		Where("bc.booking_id = ?", bookingID).
+		Limit(1).
+		Scan(&graceDays).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if graceDays.Valid {
+		return int(graceDays.Int64), nil
+	}
+	return 0, nil
+}
+
+func (r *ClientStorage) CreateBookingCategory(ctx context.Context, bookingCategory *clientModel.BookingCategory) error {
+	return r.DB.WithContext(ctx).Create(bookingCategory).Error
+}
+
+func (r *ClientStorage) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&models.User{}).
+		Where("user_id = ? AND role = ?", userID, "admin").
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ClientStorage) SetReleasePolicy(ctx context.Context, policy *clientModel.ReleasePolicy) error {
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "category_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"grace_days", "updated_at"}),
+		}).
+		Create(policy).Error
+}
+
+func (r *ClientStorage) CreateNotification(ctx context.Context, notification *clientModel.Notification) error {
+	return r.DB.WithContext(ctx).Create(notification).Error
+}
+
+func (r *ClientStorage) CreateDispute(ctx context.Context, dispute *clientModel.Dispute) error {
+	return r.DB.WithContext(ctx).Create(dispute).Error
+}
+
+func (r *ClientStorage) GetDisputeByID(ctx context.Context, disputeID string) (*clientModel.Dispute, error) {
+	var dispute clientModel.Dispute
+	err := r.DB.WithContext(ctx).
+		Preload("Attachments").
+		Where("dispute_id = ?", disputeID).
+		First(&dispute).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+func (r *ClientStorage) HasOpenDispute(ctx context.Context, bookingID string) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.Dispute{}).
+		Where("booking_id = ? AND status IN ?", bookingID, []string{"open", "responded"}).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *ClientStorage) AddDisputeResponse(ctx context.Context, disputeID, response string, attachments []clientModel.DisputeAttachment) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Model(&clientModel.Dispute{}).
+		Where("dispute_id = ?", disputeID).
+		Updates(map[string]interface{}{
+			"vendor_response": response,
+			"status":          "responded",
+		}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update dispute: %w", err)
+	}
+
+	if len(attachments) > 0 {
+		if err := tx.Create(&attachments).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to save dispute attachments: %w", err)
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func creditWallet(tx *gorm.DB, column string, userID uuid.UUID, amount int) error {
+	var wallet vendorModel.Wallet
+	err := tx.Where(column+" = ?", userID).First(&wallet).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		wallet = vendorModel.Wallet{
+			WalletBalance: int64(amount),
+			TotalDeposits: int64(amount),
+		}
+		if column == "vendor_id" {
+			wallet.VendorID = userID
+		} else {
+			wallet.ClientID = userID
+		}
+		return tx.Create(&wallet).Error
+	} else if err != nil {
+		return err
+	}
+
+	return tx.Model(&vendorModel.Wallet{}).
+		Where(column+" = ?", userID).
+		Updates(map[string]interface{}{
+			"wallet_balance": gorm.Expr("wallet_balance + ?", amount),
+			"total_deposits": gorm.Expr("total_deposits + ?", amount),
+		}).Error
+}
+
+func debitAdminWallet(tx *gorm.DB, adminEmail string, amount int) error {
+	var adminWallet adminModel.AdminWallet
+	err := tx.Where("email = ?", adminEmail).First(&adminWallet).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("admin wallet not found")
+	} else if err != nil {
+		return err
+	}
+
+	if adminWallet.Balance < float64(amount) {
+		return fmt.Errorf("admin wallet does not have enough balance")
+	}
+
+	if err := tx.Model(&adminModel.AdminWallet{}).
+		Where("email = ?", adminEmail).
+		Updates(map[string]interface{}{
+			"balance":           gorm.Expr("balance - ?", amount),
+			"total_withdrawals": gorm.Expr("total_withdrawals + ?", amount),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update admin wallet: %w", err)
+	}
+
+	return nil
+}
+
+// SettleDispute claims the dispute and the booking's escrow before moving any
+// money. It reports false when another settlement or release got there first.
+func (r *ClientStorage) SettleDispute(ctx context.Context, adminEmail string, dispute *clientModel.Dispute, refundAmount, releaseAmount int, bookingStatus string) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	result := tx.Model(&clientModel.Dispute{}).
+		Where("dispute_id = ? AND status <> ?", dispute.DisputeID, "resolved").
+		Updates(map[string]interface{}{
+			"status":        "resolved",
+			"resolution":    dispute.Resolution,
+			"refund_amount": refundAmount,
+			"resolved_by":   dispute.ResolvedBy,
+			"resolved_at":   time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to resolve dispute: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	result = tx.Model(&adminModel.Booking{}).
+		Where("booking_id = ? AND is_fund_released = ?", dispute.BookingID, false).
+		Updates(map[string]interface{}{
+			"status":           bookingStatus,
+			"is_fund_released": true,
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update booking: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := debitAdminWallet(tx, adminEmail, refundAmount+releaseAmount); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if refundAmount > 0 {
+		if err := creditWallet(tx, "client_id", dispute.ClientID, refundAmount); err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to credit client wallet: %w", err)
+		}
+
+		clientTransaction := clientModel.Transaction{
+			UserID:        dispute.ClientID,
+			Purpose:       "Dispute Refund",
+			AmountPaid:    refundAmount,
+			PaymentMethod: "wallet",
+			PaymentStatus: "refunded",
+			DateOfPayment: time.Now(),
+		}
+		if err := tx.Create(&clientTransaction).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+
+		adminTransaction := adminModel.AdminWalletTransaction{
+			Date:   time.Now(),
+			Type:   "Dispute Refund",
+			Amount: float64(refundAmount),
+			Status: "withdrawn",
+		}
+		if err := tx.Create(&adminTransaction).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	if releaseAmount > 0 {
+		if err := creditWallet(tx, "vendor_id", dispute.VendorID, releaseAmount); err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to credit vendor wallet: %w", err)
+		}
+
+		vendorTransaction := clientModel.Transaction{
+			UserID:        dispute.VendorID,
+			Purpose:       "Vendor Booking Payment",
+			AmountPaid:    releaseAmount,
+			PaymentMethod: "wallet",
+			PaymentStatus: "completed",
+			DateOfPayment: time.Now(),
+		}
+		if err := tx.Create(&vendorTransaction).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+
+		adminTransaction := adminModel.AdminWalletTransaction{
+			Date:   time.Now(),
+			Type:   "Vendor Payment Release",
+			Amount: float64(releaseAmount),
+			Status: "withdrawn",
+		}
+		if err := tx.Create(&adminTransaction).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ClientStorage) CreateBookingDeadline(ctx context.Context, deadline *clientModel.BookingDeadline) error {
+	return r.DB.WithContext(ctx).Create(deadline).Error
+}
+
+func (r *ClientStorage) GetBookingDeadline(ctx context.Context, bookingID string) (*clientModel.BookingDeadline, error) {
+	var deadline clientModel.BookingDeadline
+	err := r.DB.WithContext(ctx).Where("booking_id = ?", bookingID).First(&deadline).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &deadline, nil
+}
+
+func (r *ClientStorage) MarkBookingResponded(ctx context.Context, bookingID string) error {
+	return r.DB.WithContext(ctx).
+		Model(&clientModel.BookingDeadline{}).
+		Where("booking_id = ?", bookingID).
+		Updates(map[string]interface{}{
+			"status":       "responded",
+			"responded_at": time.Now(),
+		}).Error
+}
+
+func (r *ClientStorage) GetExpiredBookingDeadlines(ctx context.Context, now time.Time) ([]clientModel.BookingDeadline, error) {
+	var deadlines []clientModel.BookingDeadline
+	err := r.DB.WithContext(ctx).
+		Where("status = ? AND respond_by < ?", "awaiting", now).
+		Find(&deadlines).Error
+	if err != nil {
+		return nil, err
+	}
+	return deadlines, nil
+}
+
+// CancelBookingWithRefund moves a pending booking to bookingStatus and refunds
+// the client. It reports false without refunding when the booking has already
+// left the pending state.
+func (r *ClientStorage) CancelBookingWithRefund(ctx context.Context, adminEmail string, booking *adminModel.Booking, bookingStatus, purpose string) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	result := tx.Model(&adminModel.Booking{}).
+		Where("booking_id = ? AND status = ?", booking.BookingID, "pending").
+		Updates(map[string]interface{}{
+			"status":     bookingStatus,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update booking: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := debitAdminWallet(tx, adminEmail, booking.Price); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := creditWallet(tx, "client_id", booking.ClientID, booking.Price); err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to credit client wallet: %w", err)
+	}
+
+	clientTransaction := clientModel.Transaction{
+		UserID:        booking.ClientID,
+		Purpose:       purpose,
+		AmountPaid:    booking.Price,
+		PaymentMethod: "wallet",
+		PaymentStatus: "refunded",
+		DateOfPayment: time.Now(),
+	}
+	if err := tx.Create(&clientTransaction).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	adminTransaction := adminModel.AdminWalletTransaction{
+		Date:   time.Now(),
+		Type:   purpose,
+		Amount: float64(booking.Price),
+		Status: "withdrawn",
+	}
+	if err := tx.Create(&adminTransaction).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Model(&clientModel.BookingDeadline{}).
+		Where("booking_id = ? AND status = ?", booking.BookingID, "awaiting").
+		Update("status", bookingStatus).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update booking deadline: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ClientStorage) ConfirmPendingBooking(ctx context.Context, bookingID string) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	result := tx.Model(&adminModel.Booking{}).
+		Where("booking_id = ? AND status = ?", bookingID, "pending").
+		Update("status", "confirmed")
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update booking: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := tx.Model(&clientModel.BookingDeadline{}).
+		Where("booking_id = ?", bookingID).
+		Updates(map[string]interface{}{
+			"status":       "responded",
+			"responded_at": time.Now(),
+		}).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update booking deadline: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ClientStorage) IsVendorSlotBooked(ctx context.Context, vendorID string, date time.Time) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&adminModel.Booking{}).
+		Where("vendor_id = ? AND date = ?", vendorID, date).
+		Where("status NOT IN ?", []string{"cancelled", "rejected", "refunded"}).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *ClientStorage) AddCartItem(ctx context.Context, item *clientModel.CartItem) error {
+	return r.DB.WithContext(ctx).Create(item).Error
+}
+
+func (r *ClientStorage) CartItemExists(ctx context.Context, clientID, serviceID string) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.CartItem{}).
+		Where("client_id = ? AND service_id = ?", clientID, serviceID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *ClientStorage) GetCartItems(ctx context.Context, clientID string) ([]resonses.CartItemDetails, error) {
+	var items []resonses.CartItemDetails
+	err := r.DB.WithContext(ctx).
+		Table("cart_items").
+		Joins("JOIN services ON services.id = cart_items.service_id").
+		Joins("JOIN user_details ON user_details.user_id = cart_items.vendor_id").
+		Where("cart_items.client_id = ?", clientID).
+		Select(`
+		cart_items.cart_item_id,
+		cart_items.vendor_id,
+		cart_items.service_id,
+		user_details.first_name,
+		user_details.last_name,
+		services.service_title,
+		services.service_price,
+		services.available_date
+	`).
+		Order("cart_items.created_at").
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *ClientStorage) DeleteCartItem(ctx context.Context, clientID, cartItemID string) error {
+	result := r.DB.WithContext(ctx).
+		Where("client_id = ? AND cart_item_id = ?", clientID, cartItemID).
+		Delete(&clientModel.CartItem{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("no cart item found with this ID")
+	}
+	return nil
+}
+
+func (r *ClientStorage) RemoveOrderedCartItems(ctx context.Context, clientID string, items []clientModel.OrderItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	pairs := make([][]interface{}, 0, len(items))
+	for _, item := range items {
+		pairs = append(pairs, []interface{}{item.VendorID, item.ServiceID})
+	}
+
+	return r.DB.WithContext(ctx).
+		Where("client_id = ?", clientID).
+		Where("(vendor_id, service_id) IN ?", pairs).
+		Delete(&clientModel.CartItem{}).Error
+}
+
+func (r *ClientStorage) CreateOrder(ctx context.Context, order *clientModel.Order) error {
+	return r.DB.WithContext(ctx).Create(order).Error
+}
+
+func (r *ClientStorage) GetOrderByID(ctx context.Context, orderID string) (*clientModel.Order, error) {
+	var order clientModel.Order
+	err := r.DB.WithContext(ctx).
+		Preload("Items").
+		Where("order_id = ?", orderID).
+		First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *ClientStorage) UpdateOrderItem(ctx context.Context, item *clientModel.OrderItem) error {
+	return r.DB.WithContext(ctx).
+		Model(&clientModel.OrderItem{}).
+		Where("order_item_id = ?", item.OrderItemID).
+		Updates(map[string]interface{}{
+			"status":     item.Status,
+			"booking_id": item.BookingID,
+		}).Error
+}
+
+// ClaimOrderPayment records the Stripe payment and moves the order to
+// processing in one transaction. It reports whether the order still has items
+// to book, which is also the case when an earlier delivery recorded the
+// payment but stopped before the order was finished.
+func (r *ClientStorage) ClaimOrderPayment(ctx context.Context, adminEmail, orderID string, payment *clientModel.Transaction) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	recorded, err := lockPaymentIntent(tx, payment.PaymentIntentID)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if recorded {
+		var order clientModel.Order
+		err := tx.Select("status").Where("order_id = ?", orderID).First(&order).Error
+		tx.Rollback()
+		if err != nil {
+			return false, err
+		}
+		return order.Status == "processing", nil
+	}
+
+	result := tx.Model(&clientModel.Order{}).
+		Where("order_id = ? AND status = ?", orderID, "pending").
+		Update("status", "processing")
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := recordStripePayment(tx, adminEmail, payment); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ClientStorage) UpdateOrderStatus(ctx context.Context, orderID, status string) error {
+	return r.DB.WithContext(ctx).Model(&clientModel.Order{}).Where("order_id = ?", orderID).Update("status", status).Error
+}
+
+func (r *ClientStorage) RefundToClientWallet(ctx context.Context, adminEmail string, clientID uuid.UUID, amount int, purpose string) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := refundToClientWallet(tx, adminEmail, clientID, amount, purpose); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func refundToClientWallet(tx *gorm.DB, adminEmail string, clientID uuid.UUID, amount int, purpose string) error {
+	if err := debitAdminWallet(tx, adminEmail, amount); err != nil {
+		return err
+	}
+
+	if err := creditWallet(tx, "client_id", clientID, amount); err != nil {
+		return fmt.Errorf("failed to credit client wallet: %w", err)
+	}
+
+	clientTransaction := clientModel.Transaction{
+		UserID:        clientID,
+		Purpose:       purpose,
+		AmountPaid:    amount,
+		PaymentMethod: "wallet",
+		PaymentStatus: "refunded",
+		DateOfPayment: time.Now(),
+	}
+	if err := tx.Create(&clientTransaction).Error; err != nil {
+		return err
+	}
+
+	adminTransaction := adminModel.AdminWalletTransaction{
+		Date:   time.Now(),
+		Type:   purpose,
+		Amount: float64(amount),
+		Status: "withdrawn",
+	}
+	return tx.Create(&adminTransaction).Error
+}
+
+func (r *ClientStorage) GetBookingPolicies(ctx context.Context) ([]clientModel.BookingPolicy, error) {
+	var policies []clientModel.BookingPolicy
+	err := r.DB.WithContext(ctx).Find(&policies).Error
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *ClientStorage) SetBookingPolicy(ctx context.Context, policy *clientModel.BookingPolicy) error {
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tier"}, {Name: "service_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"daily_limit",
+				"weekly_limit",
+				"max_pending",
+				"max_tickets_per_event",
+				"cancellation_cooldown_hours",
+				"updated_at",
+			}),
+		}).
+		Create(policy).Error
+}
+
+func (r *ClientStorage) CountPendingBookings(ctx context.Context, clientID string) (int, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&adminModel.Booking{}).
+		Where("client_id = ? AND status = ?", clientID, "pending").
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *ClientStorage) CountTicketsSince(ctx context.Context, clientID string, since time.Time) (int, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Where("client_id = ? AND created_at >= ?", clientID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *ClientStorage) CountClientEventTickets(ctx context.Context, clientID, eventID string) (int, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Where("client_id = ? AND event_id = ? AND status <> ?", clientID, eventID, "cancelled").
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *ClientStorage) GetLastCancellationTime(ctx context.Context, clientID string, purposes []string) (*time.Time, error) {
+	var transaction clientModel.Transaction
+	err := r.DB.WithContext(ctx).
+		Where("user_id = ? AND purpose IN ?", clientID, purposes).
+		Order("date_of_payment DESC").
+		First(&transaction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &transaction.DateOfPayment, nil
+}
+
+func (r *ClientStorage) GetEventByID(ctx context.Context, eventID string) (*clientModel.Event, error) {
+	var event clientModel.Event
+	err := r.DB.WithContext(ctx).Where("event_id = ?", eventID).First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *ClientStorage) UpdateEventStatus(ctx context.Context, eventID, status string) error {
+	return r.DB.WithContext(ctx).Model(&clientModel.Event{}).Where("event_id = ?", eventID).Update("status", status).Error
+}
+
+// CancelEventWithCancellation flips the event to cancelled and records the
+// cancellation together. It reports false when the event was already cancelled.
+func (r *ClientStorage) CancelEventWithCancellation(ctx context.Context, cancellation *clientModel.EventCancellation) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := lockRefundableEvent(tx, cancellation.EventID); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	result := tx.Model(&clientModel.Event{}).
+		Where("event_id = ? AND status <> ?", cancellation.EventID, "cancelled").
+		Update("status", "cancelled")
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to cancel event: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := tx.Create(cancellation).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to record event cancellation: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// lockRefundableEvent locks the event row so refunds, cancellation and
+// settlement never interleave, and refuses once the event has been settled
+// or has ended. Cancelled events keep refunding until every ticket is done.
+func lockRefundableEvent(tx *gorm.DB, eventID uuid.UUID) error {
+	var event clientModel.Event
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ?", eventID).
+		First(&event).Error
+	if err != nil {
+		return fmt.Errorf("failed to lock event: %w", err)
+	}
+
+	var settlements int64
+	if err := tx.Model(&clientModel.EventSettlement{}).Where("event_id = ?", eventID).Count(&settlements).Error; err != nil {
+		return err
+	}
+	if settlements > 0 {
+		return status.Errorf(codes.FailedPrecondition, "event has already been settled")
+	}
+
+	if event.Status != "cancelled" && time.Now().After(event.EndsAt) {
+		return status.Errorf(codes.FailedPrecondition, "event has already ended")
+	}
+	return nil
+}
+
+// ClaimEventCancellation leases an in-progress cancellation until the given
+// time so the scheduler and the request that started it do not refund the
+// same tickets side by side.
+func (r *ClientStorage) ClaimEventCancellation(ctx context.Context, eventID uuid.UUID, until time.Time) (bool, error) {
+	result := r.DB.WithContext(ctx).
+		Model(&clientModel.EventCancellation{}).
+		Where("event_id = ? AND status = ?", eventID, "in_progress").
+		Where("claimed_until IS NULL OR claimed_until < ?", time.Now()).
+		Update("claimed_until", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *ClientStorage) GetEventCancellationsInProgress(ctx context.Context) ([]clientModel.EventCancellation, error) {
+	var cancellations []clientModel.EventCancellation
+	err := r.DB.WithContext(ctx).Where("status = ?", "in_progress").Find(&cancellations).Error
+	if err != nil {
+		return nil, err
+	}
+	return cancellations, nil
+}
+
+func (r *ClientStorage) UpdateEventCancellationProgress(ctx context.Context, cancellation *clientModel.EventCancellation) error {
+	return r.DB.WithContext(ctx).
+		Model(&clientModel.EventCancellation{}).
+		Where("event_id = ?", cancellation.EventID).
+		Updates(map[string]interface{}{
+			"processed":     cancellation.Processed,
+			"failed":        cancellation.Failed,
+			"status":        cancellation.Status,
+			"claimed_until": cancellation.ClaimedUntil,
+		}).Error
+}
+
+func (r *ClientStorage) GetRefundableTickets(ctx context.Context, eventID string, skip []string, limit int) ([]clientModel.Ticket, error) {
+	var tickets []clientModel.Ticket
+	query := r.DB.WithContext(ctx).
+		Where("event_id = ? AND status NOT IN ?", eventID, []string{"cancelled", "refunded"})
+	if len(skip) > 0 {
+		query = query.Where("ticket_id NOT IN ?", skip)
+	}
+	err := query.
+		Order("created_at").
+		Limit(limit).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// ClaimTicketRefund moves the ticket to refunding before any money leaves
+// Stripe, so a concurrent cancellation or settlement cannot take it as well.
+// A ticket already in refunding is claimed again, since an earlier attempt
+// may have stopped between the Stripe refund and recording it.
+func (r *ClientStorage) ClaimTicketRefund(ctx context.Context, ticket *clientModel.Ticket) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := lockRefundableEvent(tx, ticket.EventID); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	result := tx.Model(&clientModel.Ticket{}).
+		Where("ticket_id = ? AND status NOT IN ?", ticket.TicketID, []string{"cancelled", "refunded"}).
+		Where("NOT EXISTS (SELECT 1 FROM qrs q WHERE q.ticket_id = tickets.id AND q.is_scanned = ?)", true).
+		Updates(map[string]interface{}{
+			"status":     "refunding",
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	return true, tx.Commit().Error
+}
+
+// ReleaseTicketRefund hands a claimed ticket back when the refund could not
+// be made.
+func (r *ClientStorage) ReleaseTicketRefund(ctx context.Context, ticket *clientModel.Ticket, ticketStatus string) error {
+	return r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Where("ticket_id = ? AND status = ?", ticket.TicketID, "refunding").
+		Updates(map[string]interface{}{
+			"status":     ticketStatus,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *ClientStorage) RefundTicket(ctx context.Context, adminEmail string, ticket *clientModel.Ticket, amount int, fromStatus, ticketStatus, method, purpose string) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := lockRefundableEvent(tx, ticket.EventID); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	result := tx.Model(&clientModel.Ticket{}).
+		Where("ticket_id = ? AND status = ?", ticket.TicketID, fromStatus).
+		Where("NOT EXISTS (SELECT 1 FROM qrs q WHERE q.ticket_id = tickets.id AND q.is_scanned = ?)", true).
+		Updates(map[string]interface{}{
+			"status":     ticketStatus,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := tx.Model(&clientModel.QR{}).Where("ticket_id = ?", ticket.ID).Update("is_void", true).Error; err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to void QR code: %w", err)
+	}
+
+	if ticket.TierID != nil {
+		if err := releaseTierTickets(tx, *ticket.TierID, 1); err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to release ticket tier inventory: %w", err)
+		}
+	}
+
+	if err := debitAdminWallet(tx, adminEmail, amount); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if method == "wallet" {
+		if err := creditWallet(tx, "client_id", ticket.ClientID, amount); err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to credit client wallet: %w", err)
+		}
+	}
+
+	clientTransaction := clientModel.Transaction{
+		UserID:          ticket.ClientID,
+		Purpose:         purpose,
+		AmountPaid:      amount,
+		PaymentMethod:   method,
+		PaymentStatus:   "refunded",
+		PaymentIntentID: ticket.PaymentIntentID,
+		DateOfPayment:   time.Now(),
+	}
+	if err := tx.Create(&clientTransaction).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	adminTransaction := adminModel.AdminWalletTransaction{
+		Date:   time.Now(),
+		Type:   purpose,
+		Amount: float64(amount),
+		Status: "withdrawn",
+	}
+	if err := tx.Create(&adminTransaction).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+func (r *ClientStorage) GetTicketTiersByEventIDs(ctx context.Context, eventIDs []uuid.UUID) ([]clientModel.TicketTier, error) {
+	var tiers []clientModel.TicketTier
+	if len(eventIDs) == 0 {
+		return tiers, nil
+	}
+
+	err := r.DB.WithContext(ctx).
+		Where("event_id IN ?", eventIDs).
+		Order("price ASC").
+		Find(&tiers).Error
+	if err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+func (r *ClientStorage) GetTicketTierByID(ctx context.Context, tierID string) (*clientModel.TicketTier, error) {
+	var tier clientModel.TicketTier
+	err := r.DB.WithContext(ctx).Where("id = ?", tierID).First(&tier).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *ClientStorage) SaveTicketTiers(ctx context.Context, eventID uuid.UUID, tiers []clientModel.TicketTier, removeIDs []uuid.UUID) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if len(removeIDs) > 0 {
+		result := tx.Where("event_id = ? AND id IN ? AND sold = 0", eventID, removeIDs).Delete(&clientModel.TicketTier{})
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(removeIDs)) {
+			tx.Rollback()
+			return status.Errorf(codes.FailedPrecondition, "ticket tiers with sales cannot be removed")
+		}
+	}
+
+	var existingIDs []uuid.UUID
+	if err := tx.Model(&clientModel.TicketTier{}).Where("event_id = ?", eventID).Pluck("id", &existingIDs).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	existing := make(map[uuid.UUID]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+
+	for i := range tiers {
+		tiers[i].EventID = eventID
+
+		if !existing[tiers[i].ID] {
+			if err := tx.Create(&tiers[i]).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+			continue
+		}
+
+		if err := updateTicketTier(tx, eventID, tiers[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := syncEventTicketSummary(tx, eventID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// IssueEventTickets records the Stripe payment and issues the tickets in one
+// transaction keyed on the payment intent. It reports whether the payment was
+// processed by this call and whether the tickets were issued; when the event
+// is no longer published or the tier sold out, the payment is refunded to the
+// client's wallet instead.
+func (r *ClientStorage) IssueEventTickets(ctx context.Context, adminEmail string, payment *clientModel.Transaction, eventID uuid.UUID, tierID *uuid.UUID, tickets []clientModel.Ticket, qrs []clientModel.QR) (bool, bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	recorded, err := lockPaymentIntent(tx, payment.PaymentIntentID)
+	if err != nil {
+		tx.Rollback()
+		return false, false, err
+	}
+	if recorded {
+		tx.Rollback()
+		return false, false, nil
+	}
+
+	if err := recordStripePayment(tx, adminEmail, payment); err != nil {
+		tx.Rollback()
+		return false, false, err
+	}
+
+	// A share lock lets bookings run side by side while a cancellation,
+	// which locks the event for update, waits for them to finish.
+	var event clientModel.Event
+	if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("event_id = ?", eventID).
+		First(&event).Error; err != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to lock event: %w", err)
+	}
+
+	available := event.Status == "published"
+	if available && tierID != nil {
+		available, err = reserveTierTickets(tx, *tierID, len(tickets))
+		if err != nil {
+			tx.Rollback()
+			return false, false, fmt.Errorf("failed to reserve tickets: %w", err)
+		}
+	}
+
+	if !available {
+		if err := refundToClientWallet(tx, adminEmail, payment.UserID, payment.AmountPaid, "Event Booking Refund"); err != nil {
+			tx.Rollback()
+			return false, false, err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return false, false, err
+		}
+		return true, false, nil
+	}
+
+	if err := tx.Create(&tickets).Error; err != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to create tickets: %w", err)
+	}
+
+	if err := tx.Create(&qrs).Error; err != nil {
+		tx.Rollback()
+		return false, false, fmt.Errorf("failed to create QR codes: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, false, err
+	}
+	return true, true, nil
+}
+
+func reserveTierTickets(tx *gorm.DB, tierID uuid.UUID, quantity int) (bool, error) {
+	result := tx.Model(&clientModel.TicketTier{}).
+		Where("id = ? AND sold + ? <= quantity", tierID, quantity).
+		Update("sold", gorm.Expr("sold + ?", quantity))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	var tier clientModel.TicketTier
+	if err := tx.Where("id = ?", tierID).First(&tier).Error; err != nil {
+		return false, err
+	}
+
+	if err := tx.Model(&clientModel.EventDetails{}).
+		Where("event_id = ?", tier.EventID).
+		Update("tickets_sold", gorm.Expr("tickets_sold + ?", quantity)).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func releaseTierTickets(tx *gorm.DB, tierID uuid.UUID, quantity int) error {
+	var tier clientModel.TicketTier
+	if err := tx.Where("id = ?", tierID).First(&tier).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&clientModel.TicketTier{}).
+		Where("id = ?", tierID).
+		Update("sold", gorm.Expr("GREATEST(sold - ?, 0)", quantity)).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&clientModel.EventDetails{}).
+		Where("event_id = ?", tier.EventID).
+		Update("tickets_sold", gorm.Expr("GREATEST(tickets_sold - ?, 0)", quantity)).Error
+}
+
+// updateTicketTier writes only the editable columns, and only while the new
+// capacity still covers the tickets sold, so concurrent purchases are never
+// overwritten.
+func updateTicketTier(tx *gorm.DB, eventID uuid.UUID, tier clientModel.TicketTier) error {
+	result := tx.Model(&clientModel.TicketTier{}).
+		Where("id = ? AND event_id = ? AND sold <= ?", tier.ID, eventID, tier.Quantity).
+		Where("sold = 0 OR price = ?", tier.Price).
+		Updates(map[string]interface{}{
+			"name":            tier.Name,
+			"price":           tier.Price,
+			"quantity":        tier.Quantity,
+			"per_order_limit": tier.PerOrderLimit,
+			"sale_start":      tier.SaleStart,
+			"sale_end":        tier.SaleEnd,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return status.Errorf(codes.FailedPrecondition, "ticket tier %s sold more tickets than the new capacity or its price is locked by sales", tier.Name)
+	}
+	return nil
+}
+
+func syncEventTicketSummary(tx *gorm.DB, eventID uuid.UUID) error {
+	var summary struct {
+		MinPrice int
+		Total    int
+	}
+
+	err := tx.Model(&clientModel.TicketTier{}).
+		Select("COALESCE(MIN(price), 0) AS min_price, COALESCE(SUM(quantity), 0) AS total").
+		Where("event_id = ?", eventID).
+		Scan(&summary).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&clientModel.EventDetails{}).
+		Where("event_id = ?", eventID).
+		Updates(map[string]interface{}{
+			"price_per_ticket": summary.MinPrice,
+			"ticket_limit":     summary.Total,
+		}).Error
+}
+
+func (r *ClientStorage) GetQRByTicketID(ctx context.Context, ticketID uuid.UUID) (*clientModel.QR, error) {
+	var qr clientModel.QR
+	err := r.DB.WithContext(ctx).Where("ticket_id = ?", ticketID).Order("generated_at DESC").First(&qr).Error
+	if err != nil {
+		return nil, err
+	}
+	return &qr, nil
+}
+
+func (r *ClientStorage) MarkTicketScanned(ctx context.Context, ticketID, scannerID uuid.UUID, gate string, scannedAt time.Time) (bool, error) {
+	result := r.DB.WithContext(ctx).
+		Model(&clientModel.QR{}).
+		Where("ticket_id = ? AND is_scanned = ? AND is_void = ?", ticketID, false, false).
+		Updates(map[string]interface{}{
+			"is_scanned": true,
+			"scanned_at": scannedAt,
+			"scanned_by": scannerID,
+			"scan_gate":  gate,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MergeOfflineScan records an uploaded scan when it is the earliest one seen
+// for the ticket, regardless of upload order. A later scan it replaces has
+// its accepted scan records turned into conflicts.
+func (r *ClientStorage) MergeOfflineScan(ctx context.Context, ticketID, scannerID uuid.UUID, gate string, scannedAt time.Time) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	result := tx.Model(&clientModel.QR{}).
+		Where("ticket_id = ? AND is_void = ?", ticketID, false).
+		Where("is_scanned = ? OR scanned_at > ?", false, scannedAt).
+		Updates(map[string]interface{}{
+			"is_scanned": true,
+			"scanned_at": scannedAt,
+			"scanned_by": scannerID,
+			"scan_gate":  gate,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := tx.Model(&clientModel.ScanRecord{}).
+		Where("ticket_id = ? AND result = ? AND scanned_at > ?", ticketID, "accepted", scannedAt).
+		Update("result", "conflict").Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ClientStorage) IsTicketCheckedIn(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.QR{}).
+		Where("ticket_id = ? AND is_scanned = ?", ticketID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ClientStorage) UndoTicketScan(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+	result := r.DB.WithContext(ctx).
+		Model(&clientModel.QR{}).
+		Where("ticket_id = ? AND is_scanned = ?", ticketID, true).
+		Updates(map[string]interface{}{
+			"is_scanned": false,
+			"scanned_at": nil,
+			"scanned_by": nil,
+			"scan_gate":  "",
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ClientStorage) CountEventCheckIns(ctx context.Context, eventID string) (int, int, error) {
+	var checkedIn, total int64
+
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.QR{}).
+		Where("event_id = ? AND is_scanned = ? AND is_void = ?", eventID, true, false).
+		Count(&checkedIn).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Where("event_id = ? AND status = ?", eventID, "booked").
+		Count(&total).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int(checkedIn), int(total), nil
+}
+
+func (r *ClientStorage) GetActiveQRSigningKey(ctx context.Context) (*clientModel.QRSigningKey, error) {
+	var key clientModel.QRSigningKey
+	err := r.DB.WithContext(ctx).
+		Where("status = ?", "active").
+		Order("created_at DESC").
+		First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *ClientStorage) GetQRSigningKeys(ctx context.Context) ([]clientModel.QRSigningKey, error) {
+	var keys []clientModel.QRSigningKey
+	err := r.DB.WithContext(ctx).Order("created_at DESC").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *ClientStorage) RotateQRSigningKey(ctx context.Context, key *clientModel.QRSigningKey) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Model(&clientModel.QRSigningKey{}).
+		Where("status = ?", "active").
+		Updates(map[string]interface{}{
+			"status":     "retired",
+			"retired_at": time.Now(),
+		}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	key.Status = "active"
+	if err := tx.Create(key).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) GetQRByPayload(ctx context.Context, payload string) (*clientModel.QR, error) {
+	var qr clientModel.QR
+	err := r.DB.WithContext(ctx).Where("payload = ?", payload).First(&qr).Error
+	if err != nil {
+		return nil, err
+	}
+	return &qr, nil
+}
+
+func (r *ClientStorage) ReplaceTicketQR(ctx context.Context, ticketID uuid.UUID, qr *clientModel.QR) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Model(&clientModel.QR{}).
+		Where("ticket_id = ? AND is_void = ?", ticketID, false).
+		Update("is_void", true).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Create(qr).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetTicketsWithUnsignedQR finds booked tickets that only have a legacy
+// unsigned QR code. Tickets already checked in are left alone so reissuing
+// cannot hand out a fresh, unscanned code.
+func (r *ClientStorage) GetTicketsWithUnsignedQR(ctx context.Context, limit int) ([]clientModel.Ticket, error) {
+	var tickets []clientModel.Ticket
+	err := r.DB.WithContext(ctx).
+		Where("status = ?", "booked").
+		Where("NOT EXISTS (SELECT 1 FROM qrs q WHERE q.ticket_id = tickets.id AND q.is_void = ? AND q.payload <> '')", false).
+		Where("NOT EXISTS (SELECT 1 FROM qrs q WHERE q.ticket_id = tickets.id AND q.is_scanned = ?)", true).
+		Order("created_at").
+		Limit(limit).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *ClientStorage) eventTicketHoldersQuery(ctx context.Context, eventID string) *gorm.DB {
+	return r.DB.WithContext(ctx).
+		Table("tickets").
+		Joins("LEFT JOIN user_details ON user_details.user_id = tickets.client_id").
+		Joins("LEFT JOIN users ON users.user_id = tickets.client_id").
+		Joins("LEFT JOIN ticket_tiers ON ticket_tiers.id = tickets.tier_id").
+		Joins("LEFT JOIN qrs ON qrs.ticket_id = tickets.id AND qrs.is_void = ?", false).
+		Where("tickets.event_id = ?", eventID)
+}
+
+const eventTicketHolderColumns = `
+		tickets.id,
+		tickets.ticket_id,
+		tickets.client_id,
+		COALESCE(user_details.first_name, '') AS first_name,
+		COALESCE(user_details.last_name, '') AS last_name,
+		users.email,
+		ticket_tiers.name AS tier_name,
+		tickets.status,
+		COALESCE(qrs.is_scanned, false) AS is_scanned,
+		qrs.scanned_at,
+		tickets.created_at
+	`
+
+func (r *ClientStorage) GetEventTicketHolders(ctx context.Context, eventID string) ([]resonses.EventTicketHolder, error) {
+	var holders []resonses.EventTicketHolder
+	err := r.eventTicketHoldersQuery(ctx, eventID).
+		Select(eventTicketHolderColumns).
+		Order("tickets.created_at").
+		Scan(&holders).Error
+	if err != nil {
+		return nil, err
+	}
+	return holders, nil
+}
+
+func (r *ClientStorage) GetEventAttendees(ctx context.Context, eventID string, filter AttendeeFilter) ([]resonses.EventTicketHolder, int, error) {
+	query := r.eventTicketHoldersQuery(ctx, eventID)
+	if filter.Status != "" {
+		query = query.Where("tickets.status = ?", filter.Status)
+	}
+	if filter.CheckedIn != nil {
+		query = query.Where("COALESCE(qrs.is_scanned, false) = ?", *filter.CheckedIn)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Export pages by keyset so rows added mid-export can't shift the offset.
+	if filter.After != nil {
+		query = query.Where(
+			"(COALESCE(user_details.last_name, ''), COALESCE(user_details.first_name, ''), tickets.created_at, tickets.id) > (?, ?, ?, ?)",
+			filter.After.LastName, filter.After.FirstName, filter.After.CreatedAt, filter.After.ID,
+		)
+	}
+
+	var holders []resonses.EventTicketHolder
+	err := query.
+		Select(eventTicketHolderColumns).
+		Order("COALESCE(user_details.last_name, ''), COALESCE(user_details.first_name, ''), tickets.created_at, tickets.id").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Scan(&holders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return holders, int(total), nil
+}
+
+func (r *ClientStorage) ScanRecordExists(ctx context.Context, ticketID uuid.UUID, deviceID string, scannedAt time.Time) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.ScanRecord{}).
+		Where("ticket_id = ? AND device_id = ? AND scanned_at = ?", ticketID, deviceID, scannedAt).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *ClientStorage) CreateScanRecord(ctx context.Context, record *clientModel.ScanRecord) error {
+	return r.DB.WithContext(ctx).Create(record).Error
+}
+
+func (r *ClientStorage) GetEventDetailsByEventID(ctx context.Context, eventID string) (*clientModel.EventDetails, error) {
+	var details clientModel.EventDetails
+	err := r.DB.WithContext(ctx).Where("event_id = ?", eventID).First(&details).Error
+	if err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+func (r *ClientStorage) CreateTicketTransfer(ctx context.Context, transfer *clientModel.TicketTransfer) error {
+	return r.DB.WithContext(ctx).Create(transfer).Error
+}
+
+func (r *ClientStorage) GetTicketTransferByID(ctx context.Context, transferID string) (*clientModel.TicketTransfer, error) {
+	var transfer clientModel.TicketTransfer
+	err := r.DB.WithContext(ctx).Where("id = ?", transferID).First(&transfer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *ClientStorage) HasPendingTicketTransfer(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.TicketTransfer{}).
+		Where("ticket_id = ? AND status = ?", ticketID, "pending").
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *ClientStorage) GetTicketTransfersByClientID(ctx context.Context, clientID string) ([]clientModel.TicketTransfer, error) {
+	var transfers []clientModel.TicketTransfer
+	err := r.DB.WithContext(ctx).
+		Where("from_client_id = ? OR to_client_id = ?", clientID, clientID).
+		Order("created_at DESC").
+		Find(&transfers).Error
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func (r *ClientStorage) UpdateTicketTransferStatus(ctx context.Context, transferID uuid.UUID, fromStatus, toStatus string) (bool, error) {
+	result := r.DB.WithContext(ctx).
+		Model(&clientModel.TicketTransfer{}).
+		Where("id = ? AND status = ?", transferID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":       toStatus,
+			"responded_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// AcceptTicketTransfer moves the ticket to the recipient and swaps its QR
+// codes in the same transaction, voiding every earlier code including legacy
+// unsigned ones so nothing the previous owner holds stays valid.
+func (r *ClientStorage) AcceptTicketTransfer(ctx context.Context, transfer *clientModel.TicketTransfer, qr *clientModel.QR) (bool, error) {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	result := tx.Model(&clientModel.TicketTransfer{}).
+		Where("id = ? AND status = ?", transfer.ID, "pending").
+		Updates(map[string]interface{}{
+			"status":       "accepted",
+			"responded_at": time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	result = tx.Model(&clientModel.Ticket{}).
+		Where("id = ? AND client_id = ? AND status = ?", transfer.TicketID, transfer.FromClientID, "booked").
+		Updates(map[string]interface{}{
+			"client_id":  transfer.ToClientID,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := tx.Model(&clientModel.QR{}).
+		Where("ticket_id = ? AND is_void = ?", transfer.TicketID, false).
+		Update("is_void", true).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Create(qr).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+func (r *ClientStorage) SearchEvents(ctx context.Context, filter EventSearchFilter) ([]clientModel.Event, []clientModel.EventDetails, error) {
+	query := r.DB.WithContext(ctx).
+		Model(&clientModel.Event{}).
+		Joins("JOIN event_details ON event_details.event_id = events.event_id").
+		Where("events.ends_at >= ? AND events.status = ?", time.Now(), "published")
+
+	if filter.Query != "" {
+		query = query.Where(`(to_tsvector('english', events.title) @@ plainto_tsquery('english', ?)
+			OR to_tsvector('english', COALESCE(event_details.description, '')) @@ plainto_tsquery('english', ?))`,
+			filter.Query, filter.Query)
+	}
+	if filter.City != "" {
+		query = query.Where("LOWER(events.location_city) = LOWER(?)", filter.City)
+	}
+	if filter.Country != "" {
+		query = query.Where("LOWER(events.location_country) = LOWER(?)", filter.Country)
+	}
+	if filter.DateFrom != nil {
+		query = query.Where("events.ends_at >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("events.starts_at <= ?", *filter.DateTo)
+	}
+	if filter.MinPrice > 0 {
+		query = query.Where("event_details.price_per_ticket >= ?", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		query = query.Where("event_details.price_per_ticket <= ?", filter.MaxPrice)
+	}
+	if filter.FreeOnly {
+		query = query.Where("event_details.price_per_ticket = ?", 0)
+	}
+	if filter.OnlyAvailable {
+		query = query.Where("event_details.tickets_sold < event_details.ticket_limit")
+	}
+
+	switch filter.SortBy {
+	case "price":
+		if filter.AfterEventID != nil {
+			query = query.Where("(event_details.price_per_ticket, events.event_id) > (?, ?)", filter.AfterNumber, *filter.AfterEventID)
+		}
+		query = query.Order("event_details.price_per_ticket ASC").Order("events.event_id ASC")
+	case "popularity":
+		if filter.AfterEventID != nil {
+			query = query.Where("(event_details.tickets_sold < ? OR (event_details.tickets_sold = ? AND events.event_id > ?))",
+				filter.AfterNumber, filter.AfterNumber, *filter.AfterEventID)
+		}
+		query = query.Order("event_details.tickets_sold DESC").Order("events.event_id ASC")
+	default:
+		if filter.AfterEventID != nil {
+			query = query.Where("(events.starts_at, events.event_id) > (?, ?)", filter.AfterDate, *filter.AfterEventID)
+		}
+		query = query.Order("events.starts_at ASC").Order("events.event_id ASC")
+	}
+
+	var events []clientModel.Event
+	if err := query.Select("events.*").Limit(filter.Limit).Find(&events).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var eventIDs []uuid.UUID
+	for _, ev := range events {
+		eventIDs = append(eventIDs, ev.EventID)
+	}
+
+	var details []clientModel.EventDetails
+	if len(eventIDs) > 0 {
+		if err := r.DB.WithContext(ctx).Where("event_id IN ?", eventIDs).Find(&details).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return events, details, nil
+}
+
+const earthRadiusKm = 6371.0
+
+func (r *ClientStorage) GetEventsNearby(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]resonses.EventDistance, error) {
+	latDelta := radiusKm / 111.045
+	lngDelta := radiusKm / (111.045 * math.Max(math.Cos(lat*math.Pi/180), 0.01))
+
+	distance := `? * 2 * ASIN(SQRT(
+		POWER(SIN(RADIANS(location_lat - ?) / 2), 2) +
+		COS(RADIANS(?)) * COS(RADIANS(location_lat)) * POWER(SIN(RADIANS(location_lng - ?) / 2), 2)))`
+
+	inner := r.DB.WithContext(ctx).
+		Model(&clientModel.Event{}).
+		Select("event_id, "+distance+" AS distance_km", earthRadiusKm, lat, lat, lng).
+		Where("location_lat BETWEEN ? AND ?", lat-latDelta, lat+latDelta).
+		Where("NOT (location_lat = 0 AND location_lng = 0)").
+		Where("ends_at >= ? AND status = ?", time.Now(), "published")
+
+	// A box that crosses the antimeridian wraps around to the other side.
+	minLng, maxLng := lng-lngDelta, lng+lngDelta
+	switch {
+	case lngDelta >= 180:
+	case minLng < -180:
+		inner = inner.Where("(location_lng >= ? OR location_lng <= ?)", minLng+360, maxLng)
+	case maxLng > 180:
+		inner = inner.Where("(location_lng >= ? OR location_lng <= ?)", minLng, maxLng-360)
+	default:
+		inner = inner.Where("location_lng BETWEEN ? AND ?", minLng, maxLng)
+	}
+
+	var results []resonses.EventDistance
+	err := r.DB.WithContext(ctx).
+		Table("(?) AS nearby", inner).
+		Where("distance_km <= ?", radiusKm).
+		Order("distance_km ASC").
+		Limit(limit).
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *ClientStorage) GetEventsByIDs(ctx context.Context, eventIDs []uuid.UUID) ([]clientModel.Event, []clientModel.EventDetails, error) {
+	var events []clientModel.Event
+	var details []clientModel.EventDetails
+	if len(eventIDs) == 0 {
+		return events, details, nil
+	}
+
+	if err := r.DB.WithContext(ctx).Where("event_id IN ?", eventIDs).Find(&events).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := r.DB.WithContext(ctx).Where("event_id IN ?", eventIDs).Find(&details).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return events, details, nil
+}
+
+var eventRefundPurposes = []string{"Cancel Event Booking", "Event Cancelled Refund"}
+
+func (r *ClientStorage) GetEventSalesTotals(ctx context.Context, eventID string, from, to time.Time) (*resonses.EventSalesTotals, error) {
+	var totals resonses.EventSalesTotals
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Select("COUNT(*) AS tickets_sold, COALESCE(SUM(amount), 0) AS gross_revenue").
+		Where("event_id = ? AND created_at BETWEEN ? AND ?", eventID, from, to).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+func (r *ClientStorage) GetEventDailySales(ctx context.Context, eventID string, from, to time.Time, timezone string) ([]resonses.DailySales, error) {
+	var sales []resonses.DailySales
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Select("DATE(created_at AT TIME ZONE ?) AS day, COUNT(*) AS tickets, COALESCE(SUM(amount), 0) AS revenue", timezone).
+		Where("event_id = ? AND created_at BETWEEN ? AND ?", eventID, from, to).
+		Group("day").
+		Order("day").
+		Scan(&sales).Error
+	if err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
+
+func (r *ClientStorage) GetEventRefundTotal(ctx context.Context, eventID string, from, to time.Time) (int, int, error) {
+	var result struct {
+		Total int
+		Count int
+	}
+
+	intents := r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Select("DISTINCT payment_intent_id").
+		Where("event_id = ? AND payment_intent_id <> ''", eventID)
+
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.Transaction{}).
+		Select("COALESCE(SUM(amount_paid), 0) AS total, COUNT(*) AS count").
+		Where("payment_status = ? AND purpose IN ?", "refunded", eventRefundPurposes).
+		Where("payment_intent_id IN (?)", intents).
+		Where("date_of_payment BETWEEN ? AND ?", from, to).
+		Scan(&result).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return result.Total, result.Count, nil
+}
+
+func (r *ClientStorage) GetEventTierSales(ctx context.Context, eventID string, from, to time.Time) ([]resonses.TierSales, error) {
+	var sales []resonses.TierSales
+	err := r.DB.WithContext(ctx).
+		Table("ticket_tiers").
+		Joins("LEFT JOIN tickets ON tickets.tier_id = ticket_tiers.id AND tickets.created_at BETWEEN ? AND ?", from, to).
+		Where("ticket_tiers.event_id = ?", eventID).
+		Select(`
+		ticket_tiers.id AS tier_id,
+		ticket_tiers.name AS tier_name,
+		ticket_tiers.quantity,
+		COUNT(tickets.id) AS sold,
+		COALESCE(SUM(tickets.amount), 0) AS revenue
+	`).
+		Group("ticket_tiers.id, ticket_tiers.name, ticket_tiers.quantity, ticket_tiers.price").
+		Order("ticket_tiers.price").
+		Scan(&sales).Error
+	if err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
+
+func (r *ClientStorage) GetEventTicketHolderIDs(ctx context.Context, eventID string) ([]uuid.UUID, error) {
+	var clientIDs []uuid.UUID
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.Ticket{}).
+		Distinct("client_id").
+		Where("event_id = ? AND status = ?", eventID, "booked").
+		Pluck("client_id", &clientIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return clientIDs, nil
+}
+
+func (r *ClientStorage) PublishDueEvents(ctx context.Context, now time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).
+		Model(&clientModel.Event{}).
+		Where("status = ? AND publish_at <= ?", "scheduled", now).
+		Updates(map[string]interface{}{
+			"status":       "published",
+			"published_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *ClientStorage) ArchiveEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).
+		Model(&clientModel.Event{}).
+		Where("status = ? AND ends_at < ?", "published", before).
+		Update("status", "archived")
+	return result.RowsAffected, result.Error
+}
+
+func (r *ClientStorage) CreateEventSeries(ctx context.Context, series *clientModel.EventSeries, events []clientModel.Event, details []clientModel.EventDetails, tiers []clientModel.TicketTier) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Create(series).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Create(&events).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Create(&details).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(tiers) > 0 {
+		if err := tx.Create(&tiers).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) GetEventSeriesByID(ctx context.Context, seriesID string) (*clientModel.EventSeries, error) {
+	var series clientModel.EventSeries
+	if err := r.DB.WithContext(ctx).Where("series_id = ?", seriesID).First(&series).Error; err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *ClientStorage) GetSeriesEvents(ctx context.Context, seriesID string, from time.Time, statuses []string) ([]clientModel.Event, []clientModel.EventDetails, error) {
+	var events []clientModel.Event
+	err := r.DB.WithContext(ctx).
+		Where("series_id = ? AND ends_at >= ? AND status IN ?", seriesID, from, statuses).
+		Order("starts_at").
+		Find(&events).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eventIDs := make([]uuid.UUID, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+
+	var details []clientModel.EventDetails
+	if len(eventIDs) > 0 {
+		if err := r.DB.WithContext(ctx).Where("event_id IN ?", eventIDs).Find(&details).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return events, details, nil
+}
+
+// CountAssetReferences counts posters and gallery items still using a
+// Cloudinary asset, so shared assets are only deleted once unused.
+func (r *ClientStorage) CountAssetReferences(ctx context.Context, publicID string) (int64, error) {
+	var posters, media int64
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.EventDetails{}).
+		Where("poster_public_id = ?", publicID).
+		Count(&posters).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = r.DB.WithContext(ctx).
+		Model(&clientModel.EventMedia{}).
+		Where("public_id = ?", publicID).
+		Count(&media).Error
+	return posters + media, err
+}
+
+func (r *ClientStorage) GetEventSettlement(ctx context.Context, eventID string) (*clientModel.EventSettlement, error) {
+	var settlement clientModel.EventSettlement
+	err := r.DB.WithContext(ctx).Where("event_id = ?", eventID).First(&settlement).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+// CreateEventSettlement records the statement and the admin fund release in
+// one transaction. The unique event_id index rejects a second settlement.
+func (r *ClientStorage) CreateEventSettlement(ctx context.Context, settlement *clientModel.EventSettlement, fundRelease *adminModel.FundRelease) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	// Takes the same lock as refunds and cancellation.
+	var event clientModel.Event
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ?", settlement.EventID).
+		First(&event).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if event.Status == "cancelled" {
+		tx.Rollback()
+		return status.Errorf(codes.FailedPrecondition, "fund release is not available for cancelled events")
+	}
+
+	if err := tx.Create(fundRelease).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	settlement.FundReleaseID = fundRelease.ID
+	if err := tx.Create(settlement).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) GetEventMember(ctx context.Context, eventID, userID string) (*clientModel.EventMember, error) {
+	var member clientModel.EventMember
+	err := r.DB.WithContext(ctx).Where("event_id = ? AND user_id = ?", eventID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *ClientStorage) SaveEventMember(ctx context.Context, member *clientModel.EventMember) error {
+	return r.DB.WithContext(ctx).Save(member).Error
+}
+
+func (r *ClientStorage) GetEventMembers(ctx context.Context, eventID string) ([]clientModel.EventMember, error) {
+	var members []clientModel.EventMember
+	err := r.DB.WithContext(ctx).
+		Where("event_id = ? AND status IN ?", eventID, []string{"pending", "accepted"}).
+		Order("created_at").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *ClientStorage) CreateEventAuditLog(ctx context.Context, entry *clientModel.EventAuditLog) error {
+	return r.DB.WithContext(ctx).Create(entry).Error
+}
+
+func (r *ClientStorage) GetEventAuditLogs(ctx context.Context, eventID string, limit, offset int) ([]clientModel.EventAuditLog, int64, error) {
+	var total int64
+	query := r.DB.WithContext(ctx).Model(&clientModel.EventAuditLog{}).Where("event_id = ?", eventID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []clientModel.EventAuditLog
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+func (r *ClientStorage) CreateEventMedia(ctx context.Context, media *clientModel.EventMedia) error {
+	return r.DB.WithContext(ctx).Create(media).Error
+}
+
+func (r *ClientStorage) GetEventMedia(ctx context.Context, eventID string) ([]clientModel.EventMedia, error) {
+	var media []clientModel.EventMedia
+	err := r.DB.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("position").
+		Order("created_at").
+		Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *ClientStorage) GetEventMediaByID(ctx context.Context, eventID, mediaID string) (*clientModel.EventMedia, error) {
+	var media clientModel.EventMedia
+	err := r.DB.WithContext(ctx).Where("event_id = ? AND id = ?", eventID, mediaID).First(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *ClientStorage) UpdateEventMediaCaption(ctx context.Context, mediaID uuid.UUID, caption string) error {
+	return r.DB.WithContext(ctx).Model(&clientModel.EventMedia{}).Where("id = ?", mediaID).Update("caption", caption).Error
+}
+
+// SetEventCover marks the media item as the cover and mirrors it into the
+// event poster used by listings. A nil media clears both.
+func (r *ClientStorage) SetEventCover(ctx context.Context, eventID uuid.UUID, media *clientModel.EventMedia) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Model(&clientModel.EventMedia{}).Where("event_id = ?", eventID).Update("is_cover", false).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	poster := map[string]interface{}{"poster_image": "", "poster_public_id": ""}
+	if media != nil {
+		if err := tx.Model(&clientModel.EventMedia{}).Where("id = ?", media.ID).Update("is_cover", true).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		poster = map[string]interface{}{"poster_image": media.URL, "poster_public_id": media.PublicID}
+	}
+
+	if err := tx.Model(&clientModel.EventDetails{}).Where("event_id = ?", eventID).Updates(poster).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) ReorderEventMedia(ctx context.Context, eventID uuid.UUID, mediaIDs []uuid.UUID) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	for position, mediaID := range mediaIDs {
+		err := tx.Model(&clientModel.EventMedia{}).
+			Where("event_id = ? AND id = ?", eventID, mediaID).
+			Update("position", position).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) DeleteEventMedia(ctx context.Context, media *clientModel.EventMedia) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	if err := tx.Delete(&clientModel.EventMedia{}, "id = ?", media.ID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err := tx.Model(&clientModel.EventMedia{}).
+		Where("event_id = ? AND position > ?", media.EventID, media.Position).
+		Update("position", gorm.Expr("position - 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) CreateVenue(ctx context.Context, venue *clientModel.Venue) error {
+	return r.DB.WithContext(ctx).Create(venue).Error
+}
+
+func (r *ClientStorage) GetVenueByID(ctx context.Context, venueID string) (*clientModel.Venue, error) {
+	var venue clientModel.Venue
+	err := r.DB.WithContext(ctx).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Where("id = ?", venueID).
+		First(&venue).Error
+	if err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *ClientStorage) GetVenuesByOwner(ctx context.Context, ownerID string) ([]clientModel.Venue, error) {
+	var venues []clientModel.Venue
+	err := r.DB.WithContext(ctx).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Where("owner_id = ?", ownerID).
+		Order("name").
+		Find(&venues).Error
+	if err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+// UpdateVenue saves the venue and, when its address moved, copies the new
+// location into the events still to take place there.
+func (r *ClientStorage) UpdateVenue(ctx context.Context, venue *clientModel.Venue, moveEvents bool) error {
+	tx := r.DB.WithContext(ctx).Begin()
+
+	err := tx.Model(&clientModel.Venue{}).Where("id = ?", venue.ID).Updates(map[string]interface{}{
+		"name":                venue.Name,
+		"location_address":    venue.Location.Address,
+		"location_city":       venue.Location.City,
+		"location_country":    venue.Location.Country,
+		"location_lat":        venue.Location.Lat,
+		"location_lng":        venue.Location.Lng,
+		"capacity":            venue.Capacity,
+		"accessibility_notes": venue.AccessibilityNotes,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if moveEvents {
+		err := tx.Model(&clientModel.Event{}).
+			Where("venue_id = ? AND ends_at >= ? AND status <> ?", venue.ID, time.Now(), "cancelled").
+			Updates(map[string]interface{}{
+				"location_address": venue.Location.Address,
+				"location_city":    venue.Location.City,
+				"location_country": venue.Location.Country,
+				"location_lat":     venue.Location.Lat,
+				"location_lng":     venue.Location.Lng,
+			}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *ClientStorage) GetVenueEvents(ctx context.Context, venueID string, statuses []string) ([]clientModel.Event, []clientModel.EventDetails, error) {
+	var events []clientModel.Event
+	err := r.DB.WithContext(ctx).
+		Where("venue_id = ? AND ends_at >= ? AND status IN ?", venueID, time.Now(), statuses).
+		Order("starts_at").
+		Find(&events).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eventIDs := make([]uuid.UUID, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+
+	var details []clientModel.EventDetails
+	if len(eventIDs) > 0 {
+		if err := r.DB.WithContext(ctx).Where("event_id IN ?", eventIDs).Find(&details).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return events, details, nil
+}
+
+func (r *ClientStorage) GetVenueMaxTicketLimit(ctx context.Context, venueID string) (int, error) {
+	var limit int
+	err := r.DB.WithContext(ctx).
+		Model(&clientModel.EventDetails{}).
+		Select("COALESCE(MAX(event_details.ticket_limit), 0)").
+		Joins("JOIN events ON events.event_id = event_details.event_id").
+		Where("events.venue_id = ? AND events.ends_at >= ? AND events.status <> ?", venueID, time.Now(), "cancelled").
+		Scan(&limit).Error
+	return limit, err
+}
+
+func (r *ClientStorage) CreateVenuePhotos(ctx context.Context, photos []clientModel.VenuePhoto) error {
+	if len(photos) == 0 {
+		return nil
+	}
+	return r.DB.WithContext(ctx).Create(&photos).Error
+}
+
+func (r *ClientStorage) DeleteVenuePhoto(ctx context.Context, venueID, photoID string) (*clientModel.VenuePhoto, error) {
+	var photo clientModel.VenuePhoto
+	if err := r.DB.WithContext(ctx).Where("venue_id = ? AND id = ?", venueID, photoID).First(&photo).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.WithContext(ctx).Delete(&photo).Error; err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
@@ -1,9 +1,17 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	pb "github.com/AthulKrishna2501/proto-repo/client"
@@ -14,12 +22,17 @@ import (
 	"github.com/AthulKrishna2501/zyra-client-service/internals/app/config"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/core/cloudinary"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-client-service/internals/core/policy"
+	"github.com/AthulKrishna2501/zyra-client-service/internals/core/qrsign"
+	"github.com/AthulKrishna2501/zyra-client-service/internals/core/recurrence"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/core/repository"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/logger"
 	"github.com/AthulKrishna2501/zyra-client-service/internals/utils"
 	"github.com/google/uuid"
 	"github.com/stripe/stripe-go/v76"
 	"github.com/stripe/stripe-go/v76/checkout/session"
+	"github.com/stripe/stripe-go/v76/refund"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -98,16 +111,8 @@ func (s *ClientService) CreateBookingSession(ctx context.Context, req *pb.Generi
 			return nil, status.Errorf(codes.InvalidArgument, "service_id is required for vendor booking")
 		}
 
-		count, err := s.clientRepo.GetBookingCount(ctx, req.GetUserId())
-
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to check booking count :%v", err)
-		}
-
-		const MaxBookingsPerDay = 3
-
-		if count >= MaxBookingsPerDay {
-			return nil, status.Errorf(codes.PermissionDenied, "Booking limit reached for today")
+		if err := s.enforceBookingPolicy(ctx, req.GetUserId(), "vendor_booking", "", 1); err != nil {
+			return nil, err
 		}
 
 		vendorExists, err := s.clientRepo.VendorExists(ctx, req.Metadata["vendor_id"])
@@ -130,6 +135,24 @@ func (s *ClientService) CreateBookingSession(ctx context.Context, req *pb.Generi
 			return nil, status.Errorf(codes.NotFound, "service with ID %s does not exists", req.Metadata["service_id"])
 		}
 
+		serviceInfo, err := s.clientRepo.GetServiceInfo(ctx, req.Metadata["service_id"])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch service info: %v", err)
+		}
+
+		slotBooked, err := s.clientRepo.IsVendorSlotBooked(ctx, req.Metadata["vendor_id"], serviceInfo.AvailableDate)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check vendor availability: %v", err)
+		}
+
+		if slotBooked {
+			return nil, status.Errorf(codes.FailedPrecondition, "vendor is already booked on this date")
+		}
+
+		if _, err := s.resolveBookingCategory(ctx, req.Metadata["vendor_id"], req.Metadata["category_id"]); err != nil {
+			return nil, err
+		}
+
 		ServicePrice, err := s.clientRepo.GetServiceAmount(ctx, req.Metadata["service_id"])
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to get service price: %v", err)
@@ -156,9 +179,10 @@ func (s *ClientService) CreateBookingSession(ctx context.Context, req *pb.Generi
 			CancelURL:         stripe.String(s.config.STRIPE_CANCEL_URL),
 			ClientReferenceID: stripe.String(req.GetUserId()),
 			Metadata: map[string]string{
-				"user_id":    req.GetUserId(),
-				"vendor_id":  req.Metadata["vendor_id"],
-				"service_id": req.Metadata["service_id"],
+				"user_id":     req.GetUserId(),
+				"vendor_id":   req.Metadata["vendor_id"],
+				"service_id":  req.Metadata["service_id"],
+				"category_id": req.Metadata["category_id"],
 			},
 		}
 		stripeSession, err := session.New(sessionParams)
@@ -184,10 +208,58 @@ func (s *ClientService) CreateBookingSession(ctx context.Context, req *pb.Generi
 			return nil, status.Errorf(codes.NotFound, "event booking with ID %s does not exist", req.Metadata["booking_id"])
 		}
 
-		bookingAmount, err := s.clientRepo.GetEventAmount(ctx, req.Metadata["event_id"])
+		event, err := s.clientRepo.GetEventByID(ctx, req.Metadata["event_id"])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch event: %v", err)
+		}
+
+		if event.Status == "cancelled" {
+			return nil, status.Errorf(codes.FailedPrecondition, "event has been cancelled by the host")
+		}
+
+		if event.Status != "published" {
+			return nil, status.Errorf(codes.FailedPrecondition, "event is not open for booking")
+		}
+
+		if !event.EndsAt.IsZero() && time.Now().After(event.EndsAt) {
+			return nil, status.Errorf(codes.FailedPrecondition, "event has already ended")
+		}
+
+		quantity := 1
+		if q := req.Metadata["quantity"]; q != "" {
+			quantity, err = strconv.Atoi(q)
+			if err != nil || quantity < 1 {
+				return nil, status.Errorf(codes.InvalidArgument, "quantity must be a positive number")
+			}
+		}
+
+		tiers, err := s.clientRepo.GetTicketTiersByEventIDs(ctx, []uuid.UUID{event.EventID})
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to get event booking amount: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+		}
+
+		ticketName := "Event Booking"
+		tierID := ""
+		var bookingAmount float64
+		if len(tiers) > 0 {
+			tier, err := selectTicketTier(tiers, req.Metadata["tier_id"], quantity, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			tierID = tier.ID.String()
+			ticketName = fmt.Sprintf("%s - %s", event.Title, tier.Name)
+			bookingAmount = float64(tier.Price)
+		} else {
+			bookingAmount, err = s.clientRepo.GetEventAmount(ctx, req.Metadata["event_id"])
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get event booking amount: %v", err)
+			}
+		}
+
+		if err := s.enforceBookingPolicy(ctx, req.GetUserId(), "event_booking", req.Metadata["event_id"], quantity); err != nil {
+			return nil, err
 		}
+
 		totalAmount := bookingAmount * 100
 
 		sessionParams := &stripe.CheckoutSessionParams{
@@ -197,11 +269,11 @@ func (s *ClientService) CreateBookingSession(ctx context.Context, req *pb.Generi
 					PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
 						Currency: stripe.String("inr"),
 						ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
-							Name: stripe.String("Event Booking"),
+							Name: stripe.String(ticketName),
 						},
 						UnitAmount: stripe.Int64(int64(totalAmount)),
 					},
-					Quantity: stripe.Int64(1),
+					Quantity: stripe.Int64(int64(quantity)),
 				},
 			},
 			Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
@@ -211,6 +283,8 @@ func (s *ClientService) CreateBookingSession(ctx context.Context, req *pb.Generi
 			Metadata: map[string]string{
 				"user_id":  req.GetUserId(),
 				"event_id": req.Metadata["event_id"],
+				"tier_id":  tierID,
+				"quantity": strconv.Itoa(quantity),
 			},
 		}
 
@@ -255,6 +329,8 @@ func (s *ClientService) HandleStripeEvent(ctx context.Context, req *pb.StripeWeb
 		serviceID := sessionObj.Metadata["service_id"]
 		vendorID := sessionObj.Metadata["vendor_id"]
 		eventID := sessionObj.Metadata["event_id"]
+		quoteID := sessionObj.Metadata["quote_id"]
+		orderID := sessionObj.Metadata["order_id"]
 
 		s.log.Info("ServiceID and Vendor ID in HandleStripeEvent :", serviceID, vendorID)
 
@@ -269,6 +345,14 @@ func (s *ClientService) HandleStripeEvent(ctx context.Context, req *pb.StripeWeb
 			purpose = "Event Booking"
 		}
 
+		if quoteID != "" {
+			purpose = "Quote Booking"
+		}
+
+		if orderID != "" {
+			purpose = "Cart Booking"
+		}
+
 		Amount := sessionObj.AmountTotal / 100
 		if Amount == 0 {
 			Amount = int64(defaultAmount)
@@ -338,7 +422,32 @@ func (s *ClientService) HandleStripeEvent(ctx context.Context, req *pb.StripeWeb
 
 			}
 
+			refunded, err := s.refundIfOverBookingLimit(ctx, newTransaction, "vendor_booking", "", 1, serviceInfo.ServiceTitle)
+			if err != nil {
+				return nil, err
+			}
+			if refunded {
+				break
+			}
+
+			err = s.clientRepo.CreateTransaction(ctx, newTransaction)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to create transaction: %v", err)
+			}
+
+			err = s.clientRepo.CreditAmountToAdminWallet(ctx, float64(Amount), s.config.ADMIN_EMAIL)
+
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to credit amount to admin wallet %v", err)
+			}
+
+			err = s.clientRepo.CreateAdminWalletTransaction(ctx, newAdminWalletTransaction)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to create admin wallet transaction")
+			}
+
 			newBooking := &adminModel.Booking{
+				BookingID: uuid.New(),
 				ClientID:  userIdUUID,
 				VendorID:  vendorUUID,
 				Service:   serviceInfo.ServiceTitle,
@@ -353,23 +462,91 @@ func (s *ClientService) HandleStripeEvent(ctx context.Context, req *pb.StripeWeb
 				return nil, status.Errorf(codes.Internal, "failed to book vendor %v:", err)
 			}
 
-			err = s.clientRepo.CreateTransaction(ctx, newTransaction)
+			s.recordBookingCategory(ctx, newBooking, sessionObj.Metadata["category_id"])
+
+			err = s.startVendorResponseWindow(ctx, newBooking)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create transaction: %v", err)
+				return nil, status.Errorf(codes.Internal, "failed to start vendor response window: %v", err)
 			}
 
-			err = s.clientRepo.CreditAmountToAdminWallet(ctx, float64(Amount), s.config.ADMIN_EMAIL)
+		case "Event Booking":
+			newTransaction := &models.Transaction{
+				UserID:          userIdUUID,
+				Purpose:         purpose,
+				AmountPaid:      int(Amount),
+				PaymentMethod:   "stripe",
+				DateOfPayment:   time.Now(),
+				PaymentStatus:   "paid",
+				PaymentIntentID: sessionObj.PaymentIntent.ID,
+			}
+
+			quantity, err := strconv.Atoi(sessionObj.Metadata["quantity"])
+			if err != nil || quantity < 1 {
+				quantity = 1
+			}
 
+			refunded, err := s.refundIfOverBookingLimit(ctx, newTransaction, "event_booking", eventID, quantity, "ticket")
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to credit amount to admin wallet %v", err)
+				return nil, err
+			}
+			if refunded {
+				break
 			}
 
-			err = s.clientRepo.CreateAdminWalletTransaction(ctx, newAdminWalletTransaction)
+			var tierUUID *uuid.UUID
+			if tierID := sessionObj.Metadata["tier_id"]; tierID != "" {
+				parsed, err := uuid.Parse(tierID)
+				if err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid tier_id in session metadata")
+				}
+				tierUUID = &parsed
+			}
+
+			// Spread any remainder over the first tickets so the ticket amounts
+			// add up to what was paid.
+			var tickets []models.Ticket
+			var qrs []models.QR
+			perTicket := int(Amount) / quantity
+			remainder := int(Amount) % quantity
+			for i := 0; i < quantity; i++ {
+				amount := perTicket
+				if i < remainder {
+					amount++
+				}
+				ticket, qr, err := s.newEventTicket(ctx, userIdUUID, eventUUID, tierUUID, amount, sessionObj.PaymentIntent.ID)
+				if err != nil {
+					return nil, err
+				}
+				tickets = append(tickets, *ticket)
+				qrs = append(qrs, *qr)
+			}
+
+			processed, issued, err := s.clientRepo.IssueEventTickets(ctx, s.config.ADMIN_EMAIL, newTransaction, eventUUID, tierUUID, tickets, qrs)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create admin wallet transaction")
+				return nil, status.Errorf(codes.Internal, "failed to issue event tickets: %v", err)
+			}
+
+			if !processed {
+				s.log.Info("Event booking payment already processed:", sessionObj.PaymentIntent.ID)
+				break
+			}
+
+			if !issued {
+				s.notifyUser(ctx, userIdUUID, "Tickets unavailable",
+					fmt.Sprintf("The event sold out or closed for booking before your payment completed. %d has been refunded to your wallet.", Amount))
+			}
+
+		case "Quote Booking":
+			quote, err := s.clientRepo.GetQuoteByID(ctx, quoteID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to fetch quote: %v", err)
+			}
+
+			quoteRequest, err := s.clientRepo.GetQuoteRequestByID(ctx, quote.QuoteRequestID.String())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to fetch quote request: %v", err)
 			}
 
-		case "Event Booking":
 			newTransaction := &models.Transaction{
 				UserID:          userIdUUID,
 				Purpose:         purpose,
@@ -379,56 +556,143 @@ func (s *ClientService) HandleStripeEvent(ctx context.Context, req *pb.StripeWeb
 				PaymentStatus:   "paid",
 				PaymentIntentID: sessionObj.PaymentIntent.ID,
 			}
-			err = s.clientRepo.CreateTransaction(ctx, newTransaction)
+
+			refunded, err := s.refundIfOverBookingLimit(ctx, newTransaction, "vendor_booking", "", 1, quote.Title)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create event booking transaction: %v", err)
+				return nil, err
+			}
+			if refunded {
+				break
 			}
 
-			ticketID := uuid.New()
-			qrID := uuid.New()
-
-			newTicket := &models.Ticket{
-				ID:        ticketID,
-				TicketID:  ticketID.String(),
+			newBooking := &adminModel.Booking{
+				BookingID: uuid.New(),
 				ClientID:  userIdUUID,
-				EventID:   eventUUID,
-				Status:    "booked",
+				VendorID:  quote.VendorID,
+				Service:   quote.Title,
+				Date:      quoteRequest.EventDate,
+				Status:    "pending",
+				Price:     quote.Amount,
 				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
 			}
-			err = s.clientRepo.CreateTicket(ctx, newTicket)
+
+			processed, accepted, err := s.clientRepo.AcceptQuote(ctx, s.config.ADMIN_EMAIL, newTransaction, quoteID, newBooking)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create ticket: %v", err)
+				return nil, status.Errorf(codes.Internal, "failed to accept quote: %v", err)
+			}
+
+			if !processed {
+				s.log.Info("Quote payment already processed:", quoteID)
+				break
 			}
 
-			qrCode := utils.GenerateQRCode(ticketID.String())
-			newQR := &models.QR{
-				ID:          qrID,
-				UserID:      userIdUUID,
-				EventID:     eventUUID,
-				Code:        qrCode,
-				GeneratedAt: time.Now(),
-				IsScanned:   false,
+			if !accepted {
+				s.notifyUser(ctx, userIdUUID, "Quote no longer available",
+					fmt.Sprintf("The quote %s was already booked or withdrawn. %d has been refunded to your wallet.", quote.Title, Amount))
+				break
 			}
-			err = s.clientRepo.CreateQRCode(ctx, newQR)
+
+			s.recordBookingCategory(ctx, newBooking, "")
+
+			// The booking is paid for and committed, so a retry would skip it.
+			// Bookings without a deadline keep their response window open.
+			if err := s.startVendorResponseWindow(ctx, newBooking); err != nil {
+				s.log.Error("Failed to start vendor response window:", newBooking.BookingID.String(), err)
+			}
+
+		case "Cart Booking":
+			order, err := s.clientRepo.GetOrderByID(ctx, orderID)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create QR code: %v", err)
+				return nil, status.Errorf(codes.Internal, "failed to fetch order: %v", err)
 			}
 
-			newAdminWalletTransaction := &adminModel.AdminWalletTransaction{
-				Date:   time.Now(),
-				Type:   purpose,
-				Amount: float64(Amount),
-				Status: "succeeded",
+			newTransaction := &models.Transaction{
+				UserID:          userIdUUID,
+				Purpose:         purpose,
+				AmountPaid:      int(Amount),
+				PaymentMethod:   "stripe",
+				DateOfPayment:   time.Now(),
+				PaymentStatus:   "paid",
+				PaymentIntentID: sessionObj.PaymentIntent.ID,
 			}
-			err = s.clientRepo.CreateAdminWalletTransaction(ctx, newAdminWalletTransaction)
+
+			// An order left in processing was paid for by an earlier delivery
+			// that stopped part way, so its limits were already checked.
+			if order.Status == "pending" {
+				refunded, err := s.refundIfOverBookingLimit(ctx, newTransaction, "vendor_booking", "", len(order.Items), "cart")
+				if err != nil {
+					return nil, err
+				}
+				if refunded {
+					for i := range order.Items {
+						order.Items[i].Status = "refunded"
+						if err := s.clientRepo.UpdateOrderItem(ctx, &order.Items[i]); err != nil {
+							s.log.Error("Failed to update order item:", order.Items[i].OrderItemID.String(), err)
+						}
+					}
+					if err := s.clientRepo.UpdateOrderStatus(ctx, orderID, "refunded"); err != nil {
+						return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
+					}
+					break
+				}
+			}
+
+			claimed, err := s.clientRepo.ClaimOrderPayment(ctx, s.config.ADMIN_EMAIL, orderID, newTransaction)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create admin wallet transaction: %v", err)
+				return nil, status.Errorf(codes.Internal, "failed to record order payment: %v", err)
 			}
 
-			err = s.clientRepo.CreditAmountToAdminWallet(ctx, float64(Amount), s.config.ADMIN_EMAIL)
+			if !claimed {
+				s.log.Info("Order already processed:", orderID)
+				break
+			}
+
+			bookedItems := 0
+			for i := range order.Items {
+				item := &order.Items[i]
+
+				if item.Status != "pending" {
+					if item.Status == "booked" {
+						bookedItems++
+					}
+					continue
+				}
+
+				if err := s.bookOrderItem(ctx, userIdUUID, item); err != nil {
+					s.log.Error("Failed to book cart item, refunding:", item.OrderItemID.String(), err)
+
+					item.Status = "refunded"
+					if err := s.clientRepo.RefundToClientWallet(ctx, s.config.ADMIN_EMAIL, userIdUUID, item.Price, "Cart Item Refund"); err != nil {
+						s.log.Error("Failed to refund cart item:", item.OrderItemID.String(), err)
+						item.Status = "refund_failed"
+					}
+
+					s.notifyUser(ctx, userIdUUID, "Booking could not be completed",
+						fmt.Sprintf("Your %s booking could not be completed and %d has been refunded to your wallet.", item.ServiceTitle, item.Price))
+				} else {
+					bookedItems++
+				}
+
+				if err := s.clientRepo.UpdateOrderItem(ctx, item); err != nil {
+					s.log.Error("Failed to update order item:", item.OrderItemID.String(), err)
+				}
+			}
+
+			orderStatus := "completed"
+			if bookedItems == 0 {
+				orderStatus = "refunded"
+			} else if bookedItems < len(order.Items) {
+				orderStatus = "partially_refunded"
+			}
+
+			err = s.clientRepo.UpdateOrderStatus(ctx, orderID, orderStatus)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to credit amount to admin wallet: %v", err)
+				return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
+			}
+
+			err = s.clientRepo.RemoveOrderedCartItems(ctx, userIdUUID.String(), order.Items)
+			if err != nil {
+				s.log.Error("Failed to clear cart: %v", err)
 			}
 
 		}
@@ -494,15 +758,34 @@ func (s *ClientService) ClientDashboard(ctx context.Context, req *pb.LandingPage
 		})
 	}
 
-	upcomingEvents, eventDetails, err := s.clientRepo.GetUpcomingEvents(ctx)
-	if err != nil {
-		s.log.Error("Failed to fetch upcoming events: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to fetch upcoming events: %v", err)
+	var upcomingEvents []models.Event
+	detailsMap := make(map[uuid.UUID]models.EventDetails)
+	distances := make(map[uuid.UUID]float64)
+
+	if req.GetHasLocation() {
+		radius := req.GetRadiusKm()
+		if radius <= 0 || radius > maxNearbyRadiusKm {
+			radius = defaultNearbyRadiusKm
+		}
+
+		upcomingEvents, detailsMap, distances, err = s.nearbyEvents(ctx, req.GetLatitude(), req.GetLongitude(), radius, maxNearbyEvents)
+		if err != nil {
+			s.log.Error("Failed to fetch nearby events: %v", err)
+			return nil, status.Errorf(codes.Internal, "Failed to fetch nearby events: %v", err)
+		}
 	}
 
-	detailsMap := make(map[uuid.UUID]models.EventDetails)
-	for _, d := range eventDetails {
-		detailsMap[d.EventID] = d
+	if len(upcomingEvents) == 0 {
+		var eventDetails []models.EventDetails
+		upcomingEvents, eventDetails, err = s.clientRepo.GetUpcomingEvents(ctx)
+		if err != nil {
+			s.log.Error("Failed to fetch upcoming events: %v", err)
+			return nil, status.Errorf(codes.Internal, "Failed to fetch upcoming events: %v", err)
+		}
+
+		for _, d := range eventDetails {
+			detailsMap[d.EventID] = d
+		}
 	}
 
 	var eventList []*pb.Event
@@ -510,11 +793,15 @@ func (s *ClientService) ClientDashboard(ctx context.Context, req *pb.LandingPage
 		detail := detailsMap[event.EventID]
 
 		eventList = append(eventList, &pb.Event{
-			EventId:     event.EventID.String(),
-			Title:       event.Title,
-			Date:        event.Date.String(),
-			Description: detail.Description,
-			Image:       detail.PosterImage,
+			EventId:        event.EventID.String(),
+			Title:          event.Title,
+			Date:           event.Date.String(),
+			Description:    detail.Description,
+			Image:          detail.PosterImage,
+			DistanceKm:     distances[event.EventID],
+			StartsAt:       timestamppb.New(event.StartsAt),
+			Timezone:       event.Timezone,
+			LocalStartTime: localEventTime(&event, event.StartsAt),
 
 			Location: &pb.Location{
 				Address:   event.Location.Address,
@@ -564,7 +851,15 @@ func (s *ClientService) CreateEvent(ctx context.Context, req *pb.CreateEventRequ
 		return nil, status.Error(codes.Unauthenticated, "The user is not a master of ceremony")
 	}
 
-	eventDate := req.GetDate().AsTime()
+	loc, err := loadEventLocation(req.GetTimezone())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid timezone %q", req.GetTimezone())
+	}
+
+	startsAt, endsAt, err := eventSchedule(req.GetDate(), req.GetEventDetails().GetStartTime(), req.GetEventDetails().GetEndTime(), loc)
+	if err != nil {
+		return nil, err
+	}
 
 	HostedByUUID, _ := uuid.Parse(req.GetHostedBy())
 	EventUUID, _ := uuid.Parse(req.GetEventId())
@@ -572,7 +867,10 @@ func (s *ClientService) CreateEvent(ctx context.Context, req *pb.CreateEventRequ
 	event := models.Event{
 		EventID:  EventUUID,
 		Title:    req.GetTitle(),
-		Date:     eventDate,
+		Date:     localDate(startsAt, loc),
+		Timezone: loc.String(),
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
 		HostedBy: HostedByUUID,
 		Location: models.Location{
 			Address: req.GetLocation().GetAddress(),
@@ -583,39 +881,76 @@ func (s *ClientService) CreateEvent(ctx context.Context, req *pb.CreateEventRequ
 		},
 	}
 
-	posterImage := req.GetEventDetails().GetPosterImage()
+	var venue *models.Venue
+	if req.GetVenueId() != "" {
+		venue, err = s.hostVenue(ctx, req.GetVenueId(), HostedByUUID)
+		if err != nil {
+			return nil, err
+		}
+		event.Location = venue.Location
+		event.VenueID = &venue.ID
+	}
 
-	url, result, err := cloudinary.UploadImage(posterImage)
+	tiers, err := ticketTiersFromRequest(EventUUID, req.GetEventDetails().GetTicketTiers(),
+		int(req.GetEventDetails().GetPricePerTicket()), int(req.GetEventDetails().GetTicketLimit()))
 	if err != nil {
-		s.log.Error("failed to upload image to cloudinary %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to upload image to cloudinary %v", err)
+		return nil, err
 	}
 
-	s.log.Info("Image Url in cloudinary :", url)
-	s.log.Info("Result in Upload Image resp :", result)
+	// The poster holds the source image until everything else has been
+	// validated, so a rejected event never leaves an upload behind.
+	posterImage := req.GetEventDetails().GetPosterImage()
 
 	EventDetails := &models.EventDetails{
-		EventID:        EventUUID,
-		Description:    req.GetEventDetails().GetDescription(),
-		StartTime:      req.GetEventDetails().GetStartTime().AsTime(),
-		EndTime:        req.GetEventDetails().GetEndTime().AsTime(),
-		PosterImage:    url,
-		PricePerTicket: int(req.GetEventDetails().GetPricePerTicket()),
-		TicketLimit:    int(req.GetEventDetails().GetTicketLimit()),
+		EventID:             EventUUID,
+		Description:         req.GetEventDetails().GetDescription(),
+		StartTime:           startsAt.In(loc),
+		EndTime:             endsAt.In(loc),
+		PosterImage:         posterImage,
+		PricePerTicket:      tiers[0].Price,
+		TransferCutoffHours: int(req.GetEventDetails().GetTransferCutoffHours()),
 	}
 
-	if err := s.clientRepo.CreateEvent(ctx, &event); err != nil {
-		s.log.Error("Error creating event: %v", err)
+	for _, tier := range tiers {
+		if tier.Price < EventDetails.PricePerTicket {
+			EventDetails.PricePerTicket = tier.Price
+		}
+		EventDetails.TicketLimit += tier.Quantity
+	}
 
-		return nil, status.Errorf(codes.Internal, "failed to create event %v", err)
+	if err := checkVenueCapacity(venue, EventDetails.TicketLimit); err != nil {
+		return nil, err
 	}
 
-	if err := s.clientRepo.CreateLocation(ctx, &event.Location); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create location %v", err)
+	event.Status = "draft"
+	if !req.GetDraft() {
+		if err := validatePublishable(&event, EventDetails, tiers); err != nil {
+			return nil, err
+		}
+		event.Status, event.PublishAt, event.PublishedAt = publishState(req.GetPublishAt(), time.Now())
+	}
+
+	if posterImage != "" {
+		uploadedURL, result, err := cloudinary.UploadImage(posterImage)
+		if err != nil {
+			s.log.Error("failed to upload image to cloudinary %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to upload image to cloudinary %v", err)
+		}
+
+		s.log.Info("Image Url in cloudinary :", uploadedURL)
+		s.log.Info("Result in Upload Image resp :", result)
+		EventDetails.PosterImage, EventDetails.PosterPublicID = uploadedURL, result.PublicID
 	}
 
-	if err := s.clientRepo.CreateEventDetails(ctx, EventDetails); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create event details %v", err)
+	if err := s.clientRepo.CreateEvent(ctx, &event, EventDetails, tiers); err != nil {
+		s.log.Error("Error creating event: %v", err)
+
+		if EventDetails.PosterPublicID != "" {
+			if err := cloudinary.DeleteImage(EventDetails.PosterPublicID); err != nil {
+				s.log.Error("Failed to delete event poster:", EventDetails.PosterPublicID, err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create event %v", err)
 	}
 
 	return &pb.CreateEventResponse{
@@ -624,72 +959,387 @@ func (s *ClientService) CreateEvent(ctx context.Context, req *pb.CreateEventRequ
 
 }
 
+var editableEventPaths = map[string]bool{
+	"title":                               true,
+	"date":                                true,
+	"location":                            true,
+	"timezone":                            true,
+	"venue_id":                            true,
+	"event_details.description":           true,
+	"event_details.start_time":            true,
+	"event_details.end_time":              true,
+	"event_details.poster_image":          true,
+	"event_details.price_per_ticket":      true,
+	"event_details.ticket_limit":          true,
+	"event_details.ticket_tiers":          true,
+	"event_details.transfer_cutoff_hours": true,
+}
+
+func editEventPaths(req *pb.EditEventRequest) (map[string]bool, error) {
+	paths := make(map[string]bool)
+
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		for _, path := range req.GetUpdateMask().GetPaths() {
+			if !editableEventPaths[path] {
+				return nil, status.Errorf(codes.InvalidArgument, "field %s cannot be updated", path)
+			}
+			paths[path] = true
+		}
+		return paths, nil
+	}
+
+	details := req.GetEventDetails()
+	paths["title"] = req.GetTitle() != ""
+	paths["date"] = req.GetDate() != nil
+	paths["location"] = req.GetLocation() != nil
+	paths["timezone"] = req.GetTimezone() != ""
+	paths["venue_id"] = req.GetVenueId() != ""
+	paths["event_details.description"] = details.GetDescription() != ""
+	paths["event_details.start_time"] = details.GetStartTime() != nil
+	paths["event_details.end_time"] = details.GetEndTime() != nil
+	paths["event_details.poster_image"] = details.GetPosterImage() != ""
+	paths["event_details.price_per_ticket"] = details.GetPricePerTicket() != 0
+	paths["event_details.ticket_limit"] = details.GetTicketLimit() != 0
+	paths["event_details.ticket_tiers"] = len(details.GetTicketTiers()) > 0
+	paths["event_details.transfer_cutoff_hours"] = details.GetTransferCutoffHours() != 0
+	return paths, nil
+}
+
 func (s *ClientService) EditEvent(ctx context.Context, req *pb.EditEventRequest) (*pb.EditEventResponse, error) {
 	s.log.Info("Editing Event with ID :", req.GetEventId())
 
-	EventUUID, err := uuid.Parse(req.GetEventId())
-
+	event, actorID, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostedBy(), permEditEvent)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid Event ID %v", err)
+		return nil, err
+	}
+
+	if event.Status == "cancelled" {
+		return nil, status.Errorf(codes.FailedPrecondition, "cancelled events cannot be edited")
 	}
 
-	HostedUUID, err := uuid.Parse(req.GetHostedBy())
+	paths, err := editEventPaths(req)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid HostedBy ID %v", err)
+		return nil, err
 	}
 
-	event := models.Event{
-		EventID:  EventUUID,
-		Title:    req.GetTitle(),
-		Date:     req.GetDate().AsTime(),
-		HostedBy: HostedUUID,
-		Location: models.Location{
-			Address: req.GetLocation().Address,
-			City:    req.GetLocation().City,
-			Country: req.GetLocation().Country,
-			Lat:     req.GetLocation().Latitude,
-			Lng:     req.GetLocation().Longitude,
-		},
+	targets := []models.Event{*event}
+	switch req.GetScope() {
+	case "", "this":
+	case "following":
+		if event.SeriesID == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "event is not part of a series")
+		}
+		if event.HostedBy != actorID {
+			return nil, status.Errorf(codes.PermissionDenied, "only the host can edit a whole series")
+		}
+		if paths["date"] || paths["event_details.ticket_tiers"] {
+			return nil, status.Errorf(codes.InvalidArgument, "date and ticket tiers can only be edited one occurrence at a time")
+		}
+
+		targets, _, err = s.clientRepo.GetSeriesEvents(ctx, event.SeriesID.String(), event.StartsAt, []string{"draft", "scheduled", "published"})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch series events: %v", err)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "scope must be this or following")
 	}
 
-	EventDetails := &models.EventDetails{
-		EventID:        EventUUID,
-		Description:    req.GetEventDetails().GetDescription(),
-		StartTime:      req.GetEventDetails().GetStartTime().AsTime(),
-		EndTime:        req.GetEventDetails().EndTime.AsTime(),
-		PricePerTicket: int(req.GetEventDetails().GetPricePerTicket()),
-		TicketLimit:    int(req.GetEventDetails().GetTicketLimit()),
+	edits := make([]eventEdit, 0, len(targets))
+	for _, target := range targets {
+		edit, err := s.buildEventEdit(ctx, event, target, req, paths)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
 	}
 
-	if err := s.clientRepo.UpdateEvent(ctx, &event); err != nil {
+	newPosterPublicID := ""
+	if paths["event_details.poster_image"] {
+		url, result, err := cloudinary.UploadImage(req.GetEventDetails().GetPosterImage())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to upload image to cloudinary %v", err)
+		}
+		newPosterPublicID = result.PublicID
+
+		for _, edit := range edits {
+			edit.detailFields["poster_image"] = url
+			edit.detailFields["poster_public_id"] = result.PublicID
+		}
+	}
+
+	// The new poster is only kept once an event points at it.
+	discardPoster := func() {
+		if newPosterPublicID == "" {
+			return
+		}
+		if refs, err := s.clientRepo.CountAssetReferences(ctx, newPosterPublicID); err != nil || refs > 0 {
+			return
+		}
+		if err := cloudinary.DeleteImage(newPosterPublicID); err != nil {
+			s.log.Error("Failed to delete unused poster image:", newPosterPublicID, err)
+		}
+	}
+
+	updates := make([]repository.EventUpdate, 0, len(edits))
+	for _, edit := range edits {
+		updates = append(updates, repository.EventUpdate{
+			EventID:      edit.event.EventID,
+			EventFields:  edit.eventFields,
+			DetailFields: edit.detailFields,
+			Tier:         edit.defaultTier,
+		})
+	}
+
+	if err := s.clientRepo.UpdateEvents(ctx, updates); err != nil {
 		s.log.Error("Error updating event: %v", err)
+		discardPoster()
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update event: %v", err)
 	}
 
-	if err := s.clientRepo.UpdateEventDetails(ctx, EventDetails); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update event details: %v", err)
+	if paths["event_details.ticket_tiers"] {
+		if err := s.updateTicketTiers(ctx, event.EventID, actorID, req.GetEventDetails().GetTicketTiers()); err != nil {
+			return nil, err
+		}
+	}
+
+	if paths["event_details.poster_image"] {
+		deleted := make(map[string]bool)
+		for _, edit := range edits {
+			if edit.oldPosterPublicID == "" || deleted[edit.oldPosterPublicID] {
+				continue
+			}
+			deleted[edit.oldPosterPublicID] = true
+
+			refs, err := s.clientRepo.CountAssetReferences(ctx, edit.oldPosterPublicID)
+			if err != nil || refs > 0 {
+				continue
+			}
+			if err := cloudinary.DeleteImage(edit.oldPosterPublicID); err != nil {
+				s.log.Error("Failed to delete old poster image:", edit.oldPosterPublicID, err)
+			}
+		}
+	}
+
+	if paths["date"] || paths["timezone"] || paths["location"] || paths["venue_id"] || paths["event_details.start_time"] || paths["event_details.end_time"] {
+		for _, edit := range edits {
+			s.notifyEventHolders(ctx, edit.event.EventID.String(), "Event updated",
+				fmt.Sprintf("The schedule or venue for %s has changed. Please check the event details.", edit.event.Title))
+		}
+	}
+
+	var changed []string
+	for path, ok := range paths {
+		if ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	for _, edit := range edits {
+		s.recordEventAudit(ctx, edit.event.EventID, actorID, "event_edited", strings.Join(changed, ","))
+		if edit.tierChange != "" {
+			s.recordEventAudit(ctx, edit.event.EventID, actorID, "ticket_tiers_updated", edit.tierChange)
+		}
+	}
+
+	message := "Event updated successfully"
+	if len(edits) > 1 {
+		message = fmt.Sprintf("%d events updated successfully", len(edits))
 	}
 
 	return &pb.EditEventResponse{
-		Message: "Event updated successfully",
+		Message: message,
 	}, nil
 
 }
 
-func (s *ClientService) GetClientProfile(ctx context.Context, req *pb.GetClientProfileRequest) (*pb.GetClientProfileResponse, error) {
-	clientID := req.GetClientId()
+type eventEdit struct {
+	event             models.Event
+	eventFields       map[string]interface{}
+	detailFields      map[string]interface{}
+	defaultTier       *models.TicketTier
+	tierChange        string
+	oldPosterPublicID string
+}
 
-	userDetails, err := s.clientRepo.GetUserDetailsByID(ctx, clientID)
+// buildEventEdit validates the requested changes against one occurrence.
+// Later occurrences of a series keep their own day and take the new local
+// start time and duration of the edited event.
+func (s *ClientService) buildEventEdit(ctx context.Context, edited *models.Event, target models.Event, req *pb.EditEventRequest, paths map[string]bool) (eventEdit, error) {
+	edit := eventEdit{
+		event:        target,
+		eventFields:  map[string]interface{}{},
+		detailFields: map[string]interface{}{},
+	}
+
+	details, err := s.clientRepo.GetEventDetailsByEventID(ctx, target.EventID.String())
 	if err != nil {
-		s.log.Error("Failed to fetch client profile: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to fetch client profile: %v", err)
+		return edit, status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
 	}
 
-	return &pb.GetClientProfileResponse{
-		ClientId:     userDetails.UserID.String(),
-		FirstName:    userDetails.FirstName,
-		LastName:     userDetails.LastName,
-		Email:        userDetails.User.Email,
+	_, ticketsSold, err := s.clientRepo.CountEventCheckIns(ctx, target.EventID.String())
+	if err != nil {
+		return edit, status.Errorf(codes.Internal, "failed to count tickets sold: %v", err)
+	}
+
+	tiers, err := s.clientRepo.GetTicketTiersByEventIDs(ctx, []uuid.UUID{target.EventID})
+	if err != nil {
+		return edit, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	reqDetails := req.GetEventDetails()
+
+	if paths["title"] {
+		if req.GetTitle() == "" {
+			return edit, status.Errorf(codes.InvalidArgument, "title cannot be empty")
+		}
+		edit.eventFields["title"] = req.GetTitle()
+	}
+
+	if paths["date"] || paths["timezone"] || paths["event_details.start_time"] || paths["event_details.end_time"] {
+		loc, startsAt, endsAt, err := editedEventSchedule(edited, req, paths)
+		if err != nil {
+			return edit, err
+		}
+
+		if target.EventID != edited.EventID {
+			local := startsAt.In(loc)
+			days := localDayOffset(edited.StartsAt, target.StartsAt, eventLocation(edited))
+			shifted := time.Date(local.Year(), local.Month(), local.Day()+days, local.Hour(), local.Minute(), local.Second(), 0, loc)
+			startsAt, endsAt = shifted, shifted.Add(endsAt.Sub(startsAt))
+		}
+
+		local := startsAt.In(loc)
+		edit.eventFields["timezone"] = loc.String()
+		edit.eventFields["starts_at"] = startsAt
+		edit.eventFields["ends_at"] = endsAt
+		edit.eventFields["date"] = localDate(startsAt, loc)
+		edit.detailFields["start_time"] = local
+		edit.detailFields["end_time"] = endsAt.In(loc)
+	}
+
+	if paths["location"] && paths["venue_id"] {
+		return edit, status.Errorf(codes.InvalidArgument, "set either location or venue_id, not both")
+	}
+
+	// A free-form location detaches the event from its saved venue.
+	venueID := optionalUUID(target.VenueID)
+	if paths["location"] {
+		edit.eventFields["location_address"] = req.GetLocation().GetAddress()
+		edit.eventFields["location_city"] = req.GetLocation().GetCity()
+		edit.eventFields["location_country"] = req.GetLocation().GetCountry()
+		edit.eventFields["location_lat"] = req.GetLocation().GetLatitude()
+		edit.eventFields["location_lng"] = req.GetLocation().GetLongitude()
+		edit.eventFields["venue_id"] = nil
+		venueID = ""
+	}
+
+	if paths["venue_id"] {
+		venueID = req.GetVenueId()
+		edit.eventFields["venue_id"] = nil
+		if venueID != "" {
+			venue, err := s.hostVenue(ctx, venueID, target.HostedBy)
+			if err != nil {
+				return edit, err
+			}
+			edit.eventFields["venue_id"] = venue.ID
+			edit.eventFields["location_address"] = venue.Location.Address
+			edit.eventFields["location_city"] = venue.Location.City
+			edit.eventFields["location_country"] = venue.Location.Country
+			edit.eventFields["location_lat"] = venue.Location.Lat
+			edit.eventFields["location_lng"] = venue.Location.Lng
+		}
+	}
+
+	if venueID != "" && (paths["venue_id"] || paths["event_details.ticket_limit"] || paths["event_details.ticket_tiers"]) {
+		ticketLimit := details.TicketLimit
+		if paths["event_details.ticket_limit"] {
+			ticketLimit = int(reqDetails.GetTicketLimit())
+		}
+		if paths["event_details.ticket_tiers"] {
+			ticketLimit = 0
+			for _, tier := range reqDetails.GetTicketTiers() {
+				ticketLimit += int(tier.GetQuantity())
+			}
+		}
+
+		venue, err := s.clientRepo.GetVenueByID(ctx, venueID)
+		if err != nil {
+			return edit, status.Errorf(codes.NotFound, "venue not found: %v", err)
+		}
+		if err := checkVenueCapacity(venue, ticketLimit); err != nil {
+			return edit, err
+		}
+	}
+
+	if paths["event_details.description"] {
+		edit.detailFields["description"] = reqDetails.GetDescription()
+	}
+
+	if paths["event_details.transfer_cutoff_hours"] {
+		edit.detailFields["transfer_cutoff_hours"] = int(reqDetails.GetTransferCutoffHours())
+	}
+
+	// Events created without tiers get a single default tier, so the flat
+	// price and limit still work as long as that is the only tier.
+	if (paths["event_details.price_per_ticket"] || paths["event_details.ticket_limit"]) && len(tiers) > 1 {
+		return edit, status.Errorf(codes.FailedPrecondition, "event uses ticket tiers, update event_details.ticket_tiers instead")
+	}
+	if len(tiers) == 1 && (paths["event_details.price_per_ticket"] || paths["event_details.ticket_limit"]) {
+		tier := tiers[0]
+		edit.defaultTier = &tier
+	}
+
+	if paths["event_details.price_per_ticket"] {
+		if ticketsSold > 0 && int(reqDetails.GetPricePerTicket()) != details.PricePerTicket {
+			return edit, status.Errorf(codes.FailedPrecondition, "ticket price for %s cannot change after sales have started", target.Date.Format("2006-01-02"))
+		}
+		edit.detailFields["price_per_ticket"] = int(reqDetails.GetPricePerTicket())
+		if edit.defaultTier != nil {
+			edit.defaultTier.Price = int(reqDetails.GetPricePerTicket())
+		}
+	}
+
+	if paths["event_details.ticket_limit"] {
+		if int(reqDetails.GetTicketLimit()) < ticketsSold {
+			return edit, status.Errorf(codes.FailedPrecondition, "ticket limit for %s cannot be lower than the %d tickets already sold", target.Date.Format("2006-01-02"), ticketsSold)
+		}
+		edit.detailFields["ticket_limit"] = int(reqDetails.GetTicketLimit())
+		if edit.defaultTier != nil {
+			edit.defaultTier.Quantity = int(reqDetails.GetTicketLimit())
+		}
+	}
+
+	if edit.defaultTier != nil {
+		edit.tierChange = tierChange(tiers[0], *edit.defaultTier)
+	}
+
+	if paths["event_details.poster_image"] {
+		edit.oldPosterPublicID = details.PosterPublicID
+		if edit.oldPosterPublicID == "" {
+			edit.oldPosterPublicID = cloudinary.PublicIDFromURL(details.PosterImage)
+		}
+	}
+
+	return edit, nil
+}
+
+func (s *ClientService) GetClientProfile(ctx context.Context, req *pb.GetClientProfileRequest) (*pb.GetClientProfileResponse, error) {
+	clientID := req.GetClientId()
+
+	userDetails, err := s.clientRepo.GetUserDetailsByID(ctx, clientID)
+	if err != nil {
+		s.log.Error("Failed to fetch client profile: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to fetch client profile: %v", err)
+	}
+
+	return &pb.GetClientProfileResponse{
+		ClientId:     userDetails.UserID.String(),
+		FirstName:    userDetails.FirstName,
+		LastName:     userDetails.LastName,
+		Email:        userDetails.User.Email,
 		ProfileImage: userDetails.ProfileImage,
 		PhoneNumber:  userDetails.Phone,
 	}, nil
@@ -841,6 +1491,12 @@ func (s *ClientService) GetHostedEvents(ctx context.Context, req *pb.GetHostedEv
 		detailsMap[d.EventID] = d
 	}
 
+	tiersMap, err := s.ticketTiersByEvent(ctx, events)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch ticket tiers: %v", err)
+	}
+
+	now := time.Now()
 	var eventList []*pb.HostedEvent
 	for _, event := range events {
 		detail, ok := detailsMap[event.EventID]
@@ -848,6 +1504,8 @@ func (s *ClientService) GetHostedEvents(ctx context.Context, req *pb.GetHostedEv
 			continue
 		}
 
+		tiers, cheapest := ticketTierSummary(tiersMap[event.EventID], now)
+
 		eventList = append(eventList, &pb.HostedEvent{
 			EventId: event.EventID.String(),
 			Title:   event.Title,
@@ -860,10 +1518,19 @@ func (s *ClientService) GetHostedEvents(ctx context.Context, req *pb.GetHostedEv
 			},
 			Date:           timestamppb.New(event.Date),
 			Description:    detail.Description,
-			StartTime:      timestamppb.New(detail.StartTime),
-			EndTime:        timestamppb.New(detail.EndTime),
+			StartTime:      timestamppb.New(eventStartTime(&event, &detail)),
+			EndTime:        timestamppb.New(eventEndTime(&event, &detail)),
+			Timezone:       event.Timezone,
+			LocalStartTime: localEventTime(&event, eventStartTime(&event, &detail)),
+			LocalEndTime:   localEventTime(&event, eventEndTime(&event, &detail)),
 			PricePerTicket: int32(detail.PricePerTicket),
 			TicketLimit:    int32(detail.TicketLimit),
+			TicketTiers:    tiers,
+			CheapestPrice:  cheapest,
+			Status:         event.Status,
+			PublishAt:      optionalTimestamp(event.PublishAt),
+			SeriesId:       optionalUUID(event.SeriesID),
+			VenueId:        optionalUUID(event.VenueID),
 		})
 	}
 
@@ -884,28 +1551,15 @@ func (s *ClientService) GetUpcomingEvents(ctx context.Context, req *pb.GetUpcomi
 		detailsMap[detail.EventID] = detail
 	}
 
+	tiersMap, err := s.ticketTiersByEvent(ctx, events)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch ticket tiers: %v", err)
+	}
+
+	now := time.Now()
 	var eventList []*pb.UpcomingEvent
 	for _, event := range events {
-		detail := detailsMap[event.EventID]
-
-		eventList = append(eventList, &pb.UpcomingEvent{
-			EventId: event.EventID.String(),
-			Title:   event.Title,
-			Location: &pb.Location{
-				Address:   event.Location.Address,
-				City:      event.Location.City,
-				Country:   event.Location.Country,
-				Latitude:  event.Location.Lat,
-				Longitude: event.Location.Lng,
-			},
-			Date:           timestamppb.New(event.Date),
-			Description:    detail.Description,
-			PosterImage:    detail.PosterImage,
-			PricePerTicket: int32(detail.PricePerTicket),
-			TicketLimit:    int32(detail.TicketLimit),
-			StartTime:      timestamppb.New(detail.StartTime),
-			EndTime:        timestamppb.New(detail.EndTime),
-		})
+		eventList = append(eventList, upcomingEventToPB(event, detailsMap[event.EventID], tiersMap[event.EventID], now))
 	}
 
 	return &pb.GetUpcomingEventsResponse{
@@ -1127,6 +1781,14 @@ func (s *ClientService) CompleteServiceBooking(ctx context.Context, req *pb.Comp
 		return nil, status.Errorf(codes.PermissionDenied, "booking does not belong to the client")
 	}
 
+	hasOpenDispute, err := s.clientRepo.HasOpenDispute(ctx, req.BookingId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check booking disputes: %v", err)
+	}
+	if hasOpenDispute {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking has an open dispute")
+	}
+
 	err = s.clientRepo.UpdateClientApprovalStatus(ctx, req.BookingId, true)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update client approval status: %v", err)
@@ -1137,14 +1799,12 @@ func (s *ClientService) CompleteServiceBooking(ctx context.Context, req *pb.Comp
 	}
 
 	if booking.IsVendorApproved && booking.IsClientApproved {
-		err = s.clientRepo.ReleasePaymentToVendor(ctx, booking.VendorID.String(), float64(booking.Price))
+		released, err := s.releaseBookingPayment(ctx, booking)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to release payment to vendor: %v", err)
+			return nil, status.Errorf(codes.Internal, "%v", err)
 		}
-
-		err = s.clientRepo.MarkBookingAsConfirmedAndReleased(ctx, req.BookingId)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to mark booking as confirmed: %v", err)
+		if !released {
+			return nil, status.Errorf(codes.FailedPrecondition, "booking payment has already been released or is under dispute")
 		}
 	}
 
@@ -1164,12 +1824,25 @@ func (s *ClientService) CancelVendorBooking(ctx context.Context, req *pb.CancelV
 		return nil, status.Errorf(codes.NotFound, "booking not found: %v", err)
 	}
 
-	if booking.Status == "rejected" || booking.Status == "completed" {
+	switch booking.Status {
+	case "rejected", "completed", "cancelled", "refunded", "partially_refunded":
 		return &pb.CancelVendorBookingResponse{
-			Message: "Booking cannot be canceled as it is already completed or rejected.",
+			Message: "Booking cannot be canceled as it is already " + booking.Status + ".",
 		}, nil
 	}
 
+	if booking.IsFundReleased {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking funds have already been released")
+	}
+
+	hasOpenDispute, err := s.clientRepo.HasOpenDispute(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check booking disputes: %v", err)
+	}
+	if hasOpenDispute {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking has an open dispute")
+	}
+
 	newTransaction := &models.Transaction{
 		UserID:        clientUUID,
 		Purpose:       "Cancel Vendor Booking",
@@ -1218,55 +1891,104 @@ func (s *ClientService) CancelEvent(ctx context.Context, req *pb.CancelEventRequ
 		return nil, status.Errorf(codes.InvalidArgument, "failed to parse clien_id")
 	}
 
-	eventUUID, err := uuid.Parse(req.GetEventId())
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "failed to parse event_id")
-	}
-	eventAmount, err := s.clientRepo.GetEventAmount(ctx, req.GetEventId())
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch event amount: %v", err)
-	}
+	var tickets []models.Ticket
+	if len(req.GetTicketIds()) > 0 {
+		for _, ticketID := range req.GetTicketIds() {
+			ticket, err := s.clientRepo.GetTicketByTicketID(ctx, ticketID)
+			if err != nil {
+				return nil, status.Errorf(codes.NotFound, "ticket %s not found: %v", ticketID, err)
+			}
 
-	newTransaction := &models.Transaction{
-		UserID:        clientUUID,
-		Purpose:       "Cancel Event Booking",
-		AmountPaid:    int(eventAmount),
-		PaymentMethod: "wallet",
-		DateOfPayment: time.Now(),
-		PaymentStatus: "refunded",
-	}
+			if ticket.ClientID != clientUUID {
+				return nil, status.Errorf(codes.PermissionDenied, "ticket %s does not belong to the user", ticketID)
+			}
 
-	newAdminWalletTransaction := &adminModel.AdminWalletTransaction{
-		Date:   time.Now(),
-		Type:   "Cancel Event Booking",
-		Amount: eventAmount,
-		Status: "withdrawn",
-	}
+			tickets = append(tickets, *ticket)
+		}
+	} else {
+		if _, err := uuid.Parse(req.GetEventId()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "either ticket_ids or a valid event_id is required")
+		}
 
-	err = s.clientRepo.CreateTransaction(ctx, newTransaction)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create transaction: %v", err)
+		tickets, err = s.clientRepo.GetActiveClientEventTickets(ctx, clientUUID.String(), req.GetEventId())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch tickets: %v", err)
+		}
+
+		if len(tickets) == 0 {
+			return nil, status.Errorf(codes.NotFound, "no active tickets found for this event")
+		}
 	}
 
-	err = s.clientRepo.CreateAdminWalletTransaction(ctx, newAdminWalletTransaction)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create admin wallet transaction")
+	checkedEvents := make(map[uuid.UUID]bool)
+	for i := range tickets {
+		checkedIn, err := s.clientRepo.IsTicketCheckedIn(ctx, tickets[i].ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check ticket %s: %v", tickets[i].TicketID, err)
+		}
+		if checkedIn {
+			return nil, status.Errorf(codes.FailedPrecondition, "ticket %s has already been checked in", tickets[i].TicketID)
+		}
+
+		if !checkedEvents[tickets[i].EventID] {
+			checkedEvents[tickets[i].EventID] = true
+			event, err := s.clientRepo.GetEventByID(ctx, tickets[i].EventID.String())
+			if err != nil {
+				return nil, status.Errorf(codes.NotFound, "event not found: %v", err)
+			}
+			if err := s.checkEventRefundable(ctx, event); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	err = s.clientRepo.RefundAmount(ctx, s.config.ADMIN_EMAIL, clientUUID.String(), int(eventAmount))
+	var cancelled, alreadyCancelled []string
+	refunded := 0
+	for i := range tickets {
+		ticket := &tickets[i]
 
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to refund amount %v", err)
+		if ticket.Status != "booked" {
+			alreadyCancelled = append(alreadyCancelled, ticket.TicketID)
+			continue
+		}
+
+		amount := ticket.Amount
+		if amount == 0 {
+			eventAmount, err := s.clientRepo.GetEventAmount(ctx, ticket.EventID.String())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to fetch event amount: %v", err)
+			}
+			amount = int(eventAmount)
+		}
+
+		ok, err := s.clientRepo.RefundTicket(ctx, s.config.ADMIN_EMAIL, ticket, amount, "booked", "cancelled", "wallet", "Cancel Event Booking")
+		if err != nil {
+			if status.Code(err) == codes.FailedPrecondition {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Internal, "failed to cancel ticket %s: %v", ticket.TicketID, err)
+		}
+
+		if !ok {
+			alreadyCancelled = append(alreadyCancelled, ticket.TicketID)
+			continue
+		}
+
+		cancelled = append(cancelled, ticket.TicketID)
+		refunded += amount
 	}
 
-	err = s.clientRepo.UpdateTicket(ctx, eventUUID.String(), "cancelled")
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update booking status: %v", err)
+	message := "Event booking cancelled successfully"
+	if len(cancelled) == 0 {
+		message = "Tickets were already cancelled"
 	}
 
 	return &pb.CancelEventResponse{
-			Message: "Event booking cancelled successfully"},
-		nil
+		Message:                   message,
+		CancelledTicketIds:        cancelled,
+		AlreadyCancelledTicketIds: alreadyCancelled,
+		RefundedAmount:            int32(refunded),
+	}, nil
 }
 
 func (s *ClientService) GetBookedTickets(ctx context.Context, req *pb.GetBookedTicketsRequest) (*pb.GetBookedTicketsResponse, error) {
@@ -1301,48 +2023,4537 @@ func (s *ClientService) GetBookedTickets(ctx context.Context, req *pb.GetBookedT
 }
 
 func (s *ClientService) RequestFundRelease(ctx context.Context, req *pb.FundReleaseRequest) (*pb.FundReleaseResponse, error) {
-	eventUUID, err := uuid.Parse(req.GetEventId())
+	event, err := s.authorizeEventHost(ctx, req.GetEventId(), req.GetClientId())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "failed to parse event_id")
+		return nil, err
 	}
-	eventName, err := s.clientRepo.GetEventNameByID(ctx, eventUUID.String())
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch event name: %v", err)
+
+	if event.Status == "cancelled" {
+		return nil, status.Errorf(codes.FailedPrecondition, "fund release is not available for cancelled events")
 	}
 
-	tickets, err := s.clientRepo.GetTicketsByEventID(ctx, eventUUID.String())
+	eventID := event.EventID.String()
+
+	existing, err := s.clientRepo.GetEventSettlement(ctx, eventID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch tickets for event: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch settlement: %v", err)
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "funds for this event were already requested on %s", existing.CreatedAt.Format(time.RFC3339))
 	}
 
-	ticketPrice, err := s.clientRepo.GetEventAmount(ctx, eventUUID.String())
+	details, err := s.clientRepo.GetEventDetailsByEventID(ctx, eventID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch ticket price: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
 	}
 
-	var totalAmount int
-	var ticketsSold int
-	for _, ticket := range tickets {
-		if ticket.Status != "cancelled" {
-			totalAmount += int(ticketPrice)
-			ticketsSold++
-		}
+	if time.Now().Before(eventEndTime(event, details)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "funds can only be requested after the event has ended")
+	}
+
+	settlement, err := s.computeEventSettlement(ctx, event)
+	if err != nil {
+		return nil, err
 	}
 
 	fundReleaseRequest := &adminModel.FundRelease{
-		EventID:   eventUUID,
-		EventName: eventName,
-		Amount:    ticketPrice,
-		Tickets:   uint(ticketsSold),
+		EventID:   event.EventID,
+		EventName: event.Title,
+		Amount:    float64(settlement.NetPayout),
+		Tickets:   uint(settlement.TicketsSold - settlement.TicketsRefunded),
 		Status:    "pending",
 	}
 
-	err = s.clientRepo.CreateFundRelease(ctx, fundReleaseRequest)
-	if err != nil {
+	if err := s.clientRepo.CreateEventSettlement(ctx, settlement, fundReleaseRequest); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, status.Errorf(codes.AlreadyExists, "funds for this event were already requested")
+		}
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create fund release request %v", err)
 	}
 
+	s.recordEventAudit(ctx, event.EventID, event.HostedBy, "fund_release_requested", fmt.Sprintf("net_payout=%d", settlement.NetPayout))
+
 	return &pb.FundReleaseResponse{
-		Message: "Request sent successfully",
+		Message:   "Request sent successfully",
+		Statement: settlementStatementToPB(settlement, event.Title),
+	}, nil
+}
+
+func (s *ClientService) GetEventSettlement(ctx context.Context, req *pb.GetEventSettlementRequest) (*pb.GetEventSettlementResponse, error) {
+	event, err := s.authorizeEventHost(ctx, req.GetEventId(), req.GetHostId())
+	if err != nil {
+		return nil, err
+	}
+
+	settlement, err := s.clientRepo.GetEventSettlement(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch settlement: %v", err)
+	}
+
+	settled := settlement != nil
+	if !settled {
+		// Not requested yet, so return a preview of what would be settled now.
+		settlement, err = s.computeEventSettlement(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.GetEventSettlementResponse{
+		Settled:   settled,
+		Statement: settlementStatementToPB(settlement, event.Title),
+	}, nil
+}
+
+func (s *ClientService) computeEventSettlement(ctx context.Context, event *models.Event) (*models.EventSettlement, error) {
+	eventID := event.EventID.String()
+	from, to := event.CreatedAt, time.Now()
+
+	totals, err := s.clientRepo.GetEventSalesTotals(ctx, eventID, from, to)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute sales: %v", err)
+	}
+
+	refunds, refundCount, err := s.clientRepo.GetEventRefundTotal(ctx, eventID, from, to)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute refunds: %v", err)
+	}
+
+	fees, commission, net := s.eventPayout(totals.GrossRevenue, refunds)
+
+	return &models.EventSettlement{
+		EventID:         event.EventID,
+		HostID:          event.HostedBy,
+		TicketsSold:     totals.TicketsSold,
+		TicketsRefunded: refundCount,
+		GrossSales:      totals.GrossRevenue,
+		Refunds:         refunds,
+		PaymentFeeRate:  s.paymentFeeRate(),
+		PaymentFees:     fees,
+		CommissionRate:  s.eventCommissionRate(),
+		Commission:      commission,
+		NetPayout:       net,
+	}, nil
+}
+
+// eventPayout splits an event's takings into processor fees, platform
+// commission and what is left for the host. Processor fees are charged on
+// every payment and are not returned on refunds, while commission only
+// applies to revenue the host keeps.
+func (s *ClientService) eventPayout(gross, refunds int) (fees, commission, net int) {
+	fees = int(math.Round(float64(gross) * s.paymentFeeRate()))
+	commission = int(math.Round(float64(gross-refunds) * s.eventCommissionRate()))
+
+	net = gross - refunds - fees - commission
+	if net < 0 {
+		net = 0
+	}
+	return fees, commission, net
+}
+
+func settlementStatementToPB(settlement *models.EventSettlement, eventName string) *pb.SettlementStatement {
+	statement := &pb.SettlementStatement{
+		EventId:         settlement.EventID.String(),
+		EventName:       eventName,
+		TicketsSold:     int32(settlement.TicketsSold),
+		TicketsRefunded: int32(settlement.TicketsRefunded),
+		GrossSales:      int32(settlement.GrossSales),
+		Refunds:         int32(settlement.Refunds),
+		PaymentFeeRate:  settlement.PaymentFeeRate,
+		PaymentFees:     int32(settlement.PaymentFees),
+		CommissionRate:  settlement.CommissionRate,
+		Commission:      int32(settlement.Commission),
+		NetPayout:       int32(settlement.NetPayout),
+	}
+	if !settlement.CreatedAt.IsZero() {
+		statement.SettledAt = timestamppb.New(settlement.CreatedAt)
+	}
+	return statement
+}
+
+func (s *ClientService) RequestQuote(ctx context.Context, req *pb.RequestQuoteRequest) (*pb.RequestQuoteResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	vendorUUID, err := uuid.Parse(req.GetVendorId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse vendor_id")
+	}
+
+	if req.GetEventDate() == nil || req.GetEventDate().AsTime().Before(time.Now()) {
+		return nil, status.Errorf(codes.InvalidArgument, "event_date must be in the future")
+	}
+
+	if req.GetGuestCount() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "guest_count must be greater than zero")
+	}
+
+	vendorExists, err := s.clientRepo.VendorExists(ctx, req.GetVendorId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check vendor exists :%v", err)
+	}
+
+	if !vendorExists {
+		return nil, status.Errorf(codes.NotFound, "vendor with ID %s does not exists ", req.GetVendorId())
+	}
+
+	quoteRequest := &models.QuoteRequest{
+		QuoteRequestID: uuid.New(),
+		ClientID:       clientUUID,
+		VendorID:       vendorUUID,
+		EventDate:      req.GetEventDate().AsTime(),
+		GuestCount:     int(req.GetGuestCount()),
+		Notes:          req.GetNotes(),
+		Status:         "open",
+	}
+
+	if err := s.clientRepo.CreateQuoteRequest(ctx, quoteRequest); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create quote request: %v", err)
+	}
+
+	return &pb.RequestQuoteResponse{
+		QuoteRequestId: quoteRequest.QuoteRequestID.String(),
+		Message:        "Quote request sent to vendor",
+	}, nil
+}
+
+func (s *ClientService) SubmitQuote(ctx context.Context, req *pb.SubmitQuoteRequest) (*pb.SubmitQuoteResponse, error) {
+	vendorUUID, err := uuid.Parse(req.GetVendorId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse vendor_id")
+	}
+
+	if len(req.GetQuotes()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one quote is required")
+	}
+
+	quoteRequest, err := s.clientRepo.GetQuoteRequestByID(ctx, req.GetQuoteRequestId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "quote request not found: %v", err)
+	}
+
+	if quoteRequest.VendorID != vendorUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "quote request does not belong to the vendor")
+	}
+
+	if quoteRequest.Status != "open" && quoteRequest.Status != "quoted" {
+		return nil, status.Errorf(codes.FailedPrecondition, "quote request is already %s", quoteRequest.Status)
+	}
+
+	var quotes []models.Quote
+	for _, offer := range req.GetQuotes() {
+		if offer.GetAmount() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quote amount must be greater than zero")
+		}
+
+		if offer.GetExpiresAt() == nil || offer.GetExpiresAt().AsTime().Before(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "quote expiry must be in the future")
+		}
+
+		quotes = append(quotes, models.Quote{
+			QuoteID:        uuid.New(),
+			QuoteRequestID: quoteRequest.QuoteRequestID,
+			VendorID:       vendorUUID,
+			Title:          offer.GetTitle(),
+			Description:    offer.GetDescription(),
+			Amount:         int(offer.GetAmount()),
+			ExpiresAt:      offer.GetExpiresAt().AsTime(),
+			Status:         "offered",
+		})
+	}
+
+	if err := s.clientRepo.CreateQuotes(ctx, quoteRequest.QuoteRequestID.String(), quotes); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to submit quotes: %v", err)
+	}
+
+	return &pb.SubmitQuoteResponse{
+		Message: "Quotes sent to client",
+	}, nil
+}
+
+func (s *ClientService) GetQuoteRequests(ctx context.Context, req *pb.GetQuoteRequestsRequest) (*pb.GetQuoteRequestsResponse, error) {
+	quoteRequests, err := s.clientRepo.GetQuoteRequestsByUserID(ctx, req.GetUserId())
+	if err != nil {
+		s.log.Error("Failed to fetch quote requests: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to fetch quote requests: %v", err)
+	}
+
+	var quoteRequestList []*pb.QuoteRequestDetails
+	for _, quoteRequest := range quoteRequests {
+		var quoteList []*pb.QuoteDetails
+		for _, quote := range quoteRequest.Quotes {
+			quoteStatus := quote.Status
+			if quoteStatus == "offered" && quote.ExpiresAt.Before(time.Now()) {
+				quoteStatus = "expired"
+			}
+
+			quoteList = append(quoteList, &pb.QuoteDetails{
+				QuoteId:     quote.QuoteID.String(),
+				Title:       quote.Title,
+				Description: quote.Description,
+				Amount:      int32(quote.Amount),
+				ExpiresAt:   timestamppb.New(quote.ExpiresAt),
+				Status:      quoteStatus,
+			})
+		}
+
+		quoteRequestList = append(quoteRequestList, &pb.QuoteRequestDetails{
+			QuoteRequestId: quoteRequest.QuoteRequestID.String(),
+			ClientId:       quoteRequest.ClientID.String(),
+			VendorId:       quoteRequest.VendorID.String(),
+			EventDate:      timestamppb.New(quoteRequest.EventDate),
+			GuestCount:     int32(quoteRequest.GuestCount),
+			Notes:          quoteRequest.Notes,
+			Status:         quoteRequest.Status,
+			Quotes:         quoteList,
+		})
+	}
+
+	return &pb.GetQuoteRequestsResponse{
+		QuoteRequests: quoteRequestList,
+	}, nil
+}
+
+func (s *ClientService) AcceptQuote(ctx context.Context, req *pb.AcceptQuoteRequest) (*pb.AcceptQuoteResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	quote, err := s.clientRepo.GetQuoteByID(ctx, req.GetQuoteId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "quote not found: %v", err)
+	}
+
+	quoteRequest, err := s.clientRepo.GetQuoteRequestByID(ctx, quote.QuoteRequestID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch quote request: %v", err)
+	}
+
+	if quoteRequest.ClientID != clientUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "quote does not belong to the client")
+	}
+
+	if quote.Status != "offered" {
+		return nil, status.Errorf(codes.FailedPrecondition, "quote is already %s", quote.Status)
+	}
+
+	if quote.ExpiresAt.Before(time.Now()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "quote has expired")
+	}
+
+	if err := s.enforceBookingPolicy(ctx, req.GetClientId(), "vendor_booking", "", 1); err != nil {
+		return nil, err
+	}
+
+	sessionParams := &stripe.CheckoutSessionParams{
+		PaymentMethodTypes: stripe.StringSlice([]string{"card"}),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{
+			{
+				PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+					Currency: stripe.String("inr"),
+					ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+						Name: stripe.String(quote.Title),
+					},
+					UnitAmount: stripe.Int64(int64(quote.Amount) * 100),
+				},
+				Quantity: stripe.Int64(1),
+			},
+		},
+		Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
+		SuccessURL:        stripe.String(fmt.Sprintf("%s&purpose=%s", s.config.STRIPE_SUCCESS_URL, "quote_booking")),
+		CancelURL:         stripe.String(s.config.STRIPE_CANCEL_URL),
+		ClientReferenceID: stripe.String(req.GetClientId()),
+		Metadata: map[string]string{
+			"user_id":   req.GetClientId(),
+			"vendor_id": quote.VendorID.String(),
+			"quote_id":  quote.QuoteID.String(),
+		},
+	}
+
+	stripeSession, err := session.New(sessionParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AcceptQuoteResponse{
+		Url: stripeSession.URL,
+	}, nil
+}
+
+const defaultReleaseGraceDays = 7
+
+func (s *ClientService) releaseBookingPayment(ctx context.Context, booking *adminModel.Booking) (bool, error) {
+	released, err := s.clientRepo.ReleaseBookingFunds(ctx, booking.BookingID.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to release payment to vendor: %w", err)
+	}
+	return released, nil
+}
+
+// recordBookingCategory stores the category the booking was made under so
+// the release grace period follows the booked service. The requested category
+// must be one of the vendor's; without one, a vendor with a single category
+// uses it and any other booking falls back to the default grace period.
+func (s *ClientService) recordBookingCategory(ctx context.Context, booking *adminModel.Booking, requested string) {
+	categoryID, err := s.resolveBookingCategory(ctx, booking.VendorID.String(), requested)
+	if err != nil || categoryID == nil {
+		return
+	}
+
+	bookingCategory := &models.BookingCategory{
+		BookingID:  booking.BookingID,
+		CategoryID: *categoryID,
+	}
+	if err := s.clientRepo.CreateBookingCategory(ctx, bookingCategory); err != nil {
+		s.log.Error("Failed to record booking category: %v", err)
+	}
+}
+
+func (s *ClientService) resolveBookingCategory(ctx context.Context, vendorID, requested string) (*uuid.UUID, error) {
+	categories, err := s.clientRepo.GetVendorCategories(ctx, vendorID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch vendor categories: %v", err)
+	}
+
+	if requested == "" {
+		if len(categories) == 1 {
+			return &categories[0].CategoryID, nil
+		}
+		return nil, nil
+	}
+
+	for i := range categories {
+		if categories[i].CategoryID.String() == requested {
+			return &categories[i].CategoryID, nil
+		}
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "vendor does not offer category %s", requested)
+}
+
+func (s *ClientService) notifyUser(ctx context.Context, userID uuid.UUID, title, message string) {
+	notification := &models.Notification{
+		UserID:  userID,
+		Title:   title,
+		Message: message,
+	}
+
+	if err := s.clientRepo.CreateNotification(ctx, notification); err != nil {
+		s.log.Error("Failed to create notification: %v", err)
+	}
+}
+
+func (s *ClientService) AutoReleaseEscrow(ctx context.Context) error {
+	bookings, err := s.clientRepo.GetBookingsAwaitingRelease(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookings awaiting release: %w", err)
+	}
+
+	defaultGraceDays := s.config.RELEASE_GRACE_DAYS
+	if defaultGraceDays <= 0 {
+		defaultGraceDays = defaultReleaseGraceDays
+	}
+
+	for i := range bookings {
+		booking := &bookings[i]
+
+		graceDays, err := s.clientRepo.GetReleaseGraceDays(ctx, booking.BookingID.String())
+		if err != nil {
+			s.log.Error("Failed to fetch release grace period: %v", err)
+			continue
+		}
+		if graceDays == 0 {
+			graceDays = defaultGraceDays
+		}
+
+		if time.Now().Before(booking.Date.AddDate(0, 0, graceDays)) {
+			continue
+		}
+
+		// The release marks the booking as client approved itself, so a
+		// refused release leaves it to be picked up again on the next run.
+		released, err := s.releaseBookingPayment(ctx, booking)
+		if err != nil {
+			s.log.Error("Failed to auto release booking payment: %v", err)
+			continue
+		}
+		if !released {
+			continue
+		}
+
+		s.log.Info("Auto released payment for booking:", booking.BookingID.String())
+
+		s.notifyUser(ctx, booking.ClientID, "Booking marked as completed",
+			fmt.Sprintf("Your %s booking was marked as completed %d days after the service date and the payment was released to the vendor.", booking.Service, graceDays))
+		s.notifyUser(ctx, booking.VendorID, "Payment released",
+			fmt.Sprintf("Payment of %d for your %s booking has been released to your wallet.", booking.Price, booking.Service))
+	}
+
+	return nil
+}
+
+func (s *ClientService) SetReleaseGracePeriod(ctx context.Context, req *pb.SetReleaseGracePeriodRequest) (*pb.SetReleaseGracePeriodResponse, error) {
+	isAdmin, err := s.clientRepo.IsAdmin(ctx, req.GetAdminId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify admin: %v", err)
+	}
+	if !isAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can set the release grace period")
+	}
+
+	categoryUUID, err := uuid.Parse(req.GetCategoryId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse category_id")
+	}
+
+	if req.GetGraceDays() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "grace_days must be greater than zero")
+	}
+
+	policy := &models.ReleasePolicy{
+		CategoryID: categoryUUID,
+		GraceDays:  int(req.GetGraceDays()),
+	}
+
+	if err := s.clientRepo.SetReleasePolicy(ctx, policy); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set release grace period: %v", err)
+	}
+
+	return &pb.SetReleaseGracePeriodResponse{
+		Message: "Release grace period updated",
+	}, nil
+}
+
+func (s *ClientService) uploadDisputeAttachments(disputeID, uploadedBy uuid.UUID, files []string) ([]models.DisputeAttachment, error) {
+	var attachments []models.DisputeAttachment
+	for _, file := range files {
+		url, result, err := cloudinary.UploadToFolder(file, "dispute_evidence")
+		if err != nil {
+			s.deleteDisputeAttachments(attachments)
+			return nil, err
+		}
+
+		attachments = append(attachments, models.DisputeAttachment{
+			DisputeID:  disputeID,
+			UploadedBy: uploadedBy,
+			PublicID:   result.PublicID,
+			URL:        url,
+		})
+	}
+	return attachments, nil
+}
+
+func (s *ClientService) deleteDisputeAttachments(attachments []models.DisputeAttachment) {
+	for _, attachment := range attachments {
+		if err := cloudinary.DeleteImage(attachment.PublicID); err != nil {
+			s.log.Error("Failed to delete dispute evidence:", attachment.PublicID, err)
+		}
+	}
+}
+
+func (s *ClientService) OpenDispute(ctx context.Context, req *pb.OpenDisputeRequest) (*pb.OpenDisputeResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	if req.GetReason() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is required")
+	}
+
+	booking, err := s.clientRepo.GetBookingById(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "booking not found: %v", err)
+	}
+
+	if booking.ClientID != clientUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "booking does not belong to the client")
+	}
+
+	if booking.IsFundReleased || booking.Status == "cancelled" || booking.Status == "rejected" {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking can no longer be disputed")
+	}
+
+	hasOpenDispute, err := s.clientRepo.HasOpenDispute(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check booking disputes: %v", err)
+	}
+	if hasOpenDispute {
+		return nil, status.Errorf(codes.AlreadyExists, "booking already has an open dispute")
+	}
+
+	disputeID := uuid.New()
+
+	attachments, err := s.uploadDisputeAttachments(disputeID, clientUUID, req.GetAttachments())
+	if err != nil {
+		s.log.Error("failed to upload dispute evidence to cloudinary %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to upload dispute evidence %v", err)
+	}
+
+	dispute := &models.Dispute{
+		DisputeID:   disputeID,
+		BookingID:   booking.BookingID,
+		ClientID:    clientUUID,
+		VendorID:    booking.VendorID,
+		Reason:      req.GetReason(),
+		Status:      "open",
+		Attachments: attachments,
+	}
+
+	if err := s.clientRepo.CreateDispute(ctx, dispute); err != nil {
+		s.deleteDisputeAttachments(attachments)
+		return nil, status.Errorf(codes.Internal, "failed to open dispute: %v", err)
+	}
+
+	s.notifyUser(ctx, booking.VendorID, "Booking disputed",
+		fmt.Sprintf("The client opened a dispute on your %s booking. Payment is on hold until it is resolved.", booking.Service))
+
+	return &pb.OpenDisputeResponse{
+		DisputeId: disputeID.String(),
+		Message:   "Dispute opened successfully",
+	}, nil
+}
+
+func (s *ClientService) RespondToDispute(ctx context.Context, req *pb.RespondToDisputeRequest) (*pb.RespondToDisputeResponse, error) {
+	vendorUUID, err := uuid.Parse(req.GetVendorId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse vendor_id")
+	}
+
+	if req.GetResponse() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "response is required")
+	}
+
+	dispute, err := s.clientRepo.GetDisputeByID(ctx, req.GetDisputeId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "dispute not found: %v", err)
+	}
+
+	if dispute.VendorID != vendorUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "dispute does not belong to the vendor")
+	}
+
+	if dispute.Status == "resolved" {
+		return nil, status.Errorf(codes.FailedPrecondition, "dispute is already resolved")
+	}
+
+	attachments, err := s.uploadDisputeAttachments(dispute.DisputeID, vendorUUID, req.GetAttachments())
+	if err != nil {
+		s.log.Error("failed to upload dispute evidence to cloudinary %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to upload dispute evidence %v", err)
+	}
+
+	err = s.clientRepo.AddDisputeResponse(ctx, dispute.DisputeID.String(), req.GetResponse(), attachments)
+	if err != nil {
+		s.deleteDisputeAttachments(attachments)
+		return nil, status.Errorf(codes.Internal, "failed to respond to dispute: %v", err)
+	}
+
+	s.notifyUser(ctx, dispute.ClientID, "Vendor responded to your dispute",
+		"The vendor has responded to your dispute. An admin will review it shortly.")
+
+	return &pb.RespondToDisputeResponse{
+		Message: "Response submitted successfully",
+	}, nil
+}
+
+func (s *ClientService) ResolveDispute(ctx context.Context, req *pb.ResolveDisputeRequest) (*pb.ResolveDisputeResponse, error) {
+	adminUUID, err := uuid.Parse(req.GetAdminId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse admin_id")
+	}
+
+	isAdmin, err := s.clientRepo.IsAdmin(ctx, req.GetAdminId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify admin: %v", err)
+	}
+	if !isAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can resolve disputes")
+	}
+
+	dispute, err := s.clientRepo.GetDisputeByID(ctx, req.GetDisputeId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "dispute not found: %v", err)
+	}
+
+	if dispute.Status == "resolved" {
+		return nil, status.Errorf(codes.FailedPrecondition, "dispute is already resolved")
+	}
+
+	booking, err := s.clientRepo.GetBookingById(ctx, dispute.BookingID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "booking not found: %v", err)
+	}
+
+	if booking.IsFundReleased {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking funds have already been released")
+	}
+
+	var refundAmount, releaseAmount int
+	var bookingStatus string
+
+	switch req.GetResolution() {
+	case "release":
+		releaseAmount = booking.Price
+		bookingStatus = "completed"
+	case "partial_refund":
+		refundAmount = int(req.GetRefundAmount())
+		if refundAmount <= 0 || refundAmount >= booking.Price {
+			return nil, status.Errorf(codes.InvalidArgument, "refund_amount must be between 0 and the booking price %d", booking.Price)
+		}
+		releaseAmount = booking.Price - refundAmount
+		bookingStatus = "partially_refunded"
+	case "refund":
+		refundAmount = booking.Price
+		bookingStatus = "refunded"
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "resolution must be one of release, partial_refund or refund")
+	}
+
+	dispute.Resolution = req.GetResolution()
+	dispute.ResolvedBy = &adminUUID
+
+	settled, err := s.clientRepo.SettleDispute(ctx, s.config.ADMIN_EMAIL, dispute, refundAmount, releaseAmount, bookingStatus)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to settle dispute: %v", err)
+	}
+	if !settled {
+		return nil, status.Errorf(codes.FailedPrecondition, "dispute is already resolved or booking funds have already been released")
+	}
+
+	s.notifyUser(ctx, dispute.ClientID, "Dispute resolved",
+		fmt.Sprintf("Your dispute on the %s booking was resolved. %d has been refunded to your wallet.", booking.Service, refundAmount))
+	s.notifyUser(ctx, dispute.VendorID, "Dispute resolved",
+		fmt.Sprintf("The dispute on your %s booking was resolved. %d has been released to your wallet.", booking.Service, releaseAmount))
+
+	return &pb.ResolveDisputeResponse{
+		Message: "Dispute resolved successfully",
+	}, nil
+}
+
+func (s *ClientService) GetDispute(ctx context.Context, req *pb.GetDisputeRequest) (*pb.GetDisputeResponse, error) {
+	dispute, err := s.clientRepo.GetDisputeByID(ctx, req.GetDisputeId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "dispute not found: %v", err)
+	}
+
+	if req.GetUserId() != dispute.ClientID.String() && req.GetUserId() != dispute.VendorID.String() {
+		isAdmin, err := s.clientRepo.IsAdmin(ctx, req.GetUserId())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to verify admin: %v", err)
+		}
+		if !isAdmin {
+			return nil, status.Errorf(codes.PermissionDenied, "dispute does not belong to the user")
+		}
+	}
+
+	var attachments []*pb.DisputeAttachment
+	for _, attachment := range dispute.Attachments {
+		attachments = append(attachments, &pb.DisputeAttachment{
+			Url:        attachment.URL,
+			UploadedBy: attachment.UploadedBy.String(),
+			UploadedAt: timestamppb.New(attachment.CreatedAt),
+		})
+	}
+
+	return &pb.GetDisputeResponse{
+		Dispute: &pb.DisputeDetails{
+			DisputeId:      dispute.DisputeID.String(),
+			BookingId:      dispute.BookingID.String(),
+			ClientId:       dispute.ClientID.String(),
+			VendorId:       dispute.VendorID.String(),
+			Reason:         dispute.Reason,
+			VendorResponse: dispute.VendorResponse,
+			Status:         dispute.Status,
+			Resolution:     dispute.Resolution,
+			RefundAmount:   int32(dispute.RefundAmount),
+			Attachments:    attachments,
+		},
+	}, nil
+}
+
+const defaultVendorResponseHours = 48
+
+func (s *ClientService) startVendorResponseWindow(ctx context.Context, booking *adminModel.Booking) error {
+	responseHours := s.config.VENDOR_RESPONSE_HOURS
+	if responseHours <= 0 {
+		responseHours = defaultVendorResponseHours
+	}
+
+	deadline := &models.BookingDeadline{
+		BookingID: booking.BookingID,
+		RespondBy: time.Now().Add(time.Duration(responseHours) * time.Hour),
+		Status:    "awaiting",
+	}
+
+	if err := s.clientRepo.CreateBookingDeadline(ctx, deadline); err != nil {
+		return err
+	}
+
+	s.notifyUser(ctx, booking.VendorID, "New booking request",
+		fmt.Sprintf("You have a new %s booking. Accept or reject it within %d hours or it will be cancelled automatically.", booking.Service, responseHours))
+
+	return nil
+}
+
+func (s *ClientService) RespondToBooking(ctx context.Context, req *pb.RespondToBookingRequest) (*pb.RespondToBookingResponse, error) {
+	vendorUUID, err := uuid.Parse(req.GetVendorId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse vendor_id")
+	}
+
+	booking, err := s.clientRepo.GetBookingById(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "booking not found: %v", err)
+	}
+
+	if booking.VendorID != vendorUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "booking does not belong to the vendor")
+	}
+
+	if booking.Status != "pending" {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking is already %s", booking.Status)
+	}
+
+	deadline, err := s.clientRepo.GetBookingDeadline(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch booking response window: %v", err)
+	}
+
+	// Bookings made before response windows existed have no deadline and stay
+	// open until the vendor answers.
+	if deadline != nil && (deadline.Status != "awaiting" || time.Now().After(deadline.RespondBy)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "response window for this booking has closed")
+	}
+
+	if !req.GetAccept() {
+		cancelled, err := s.clientRepo.CancelBookingWithRefund(ctx, s.config.ADMIN_EMAIL, booking, "rejected", "Vendor Rejected Booking")
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to reject booking: %v", err)
+		}
+		if !cancelled {
+			return nil, status.Errorf(codes.FailedPrecondition, "booking is no longer pending")
+		}
+
+		message := fmt.Sprintf("The vendor rejected your %s booking and %d has been refunded to your wallet.", booking.Service, booking.Price)
+		if req.GetReason() != "" {
+			message += " Reason: " + req.GetReason()
+		}
+		s.notifyUser(ctx, booking.ClientID, "Booking rejected", message)
+
+		return &pb.RespondToBookingResponse{
+			Message: "Booking rejected and client refunded",
+		}, nil
+	}
+
+	confirmed, err := s.clientRepo.ConfirmPendingBooking(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update booking status: %v", err)
+	}
+	if !confirmed {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking is no longer pending")
+	}
+
+	s.notifyUser(ctx, booking.ClientID, "Booking confirmed",
+		fmt.Sprintf("The vendor accepted your %s booking.", booking.Service))
+
+	return &pb.RespondToBookingResponse{
+		Message: "Booking accepted",
+	}, nil
+}
+
+func (s *ClientService) ExpireUnansweredBookings(ctx context.Context) error {
+	deadlines, err := s.clientRepo.GetExpiredBookingDeadlines(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to fetch expired booking deadlines: %w", err)
+	}
+
+	for _, deadline := range deadlines {
+		booking, err := s.clientRepo.GetBookingById(ctx, deadline.BookingID.String())
+		if err != nil {
+			s.log.Error("Failed to fetch booking for expired deadline: %v", err)
+			continue
+		}
+
+		if booking.Status != "pending" {
+			if err := s.clientRepo.MarkBookingResponded(ctx, booking.BookingID.String()); err != nil {
+				s.log.Error("Failed to close booking response window: %v", err)
+			}
+			continue
+		}
+
+		cancelled, err := s.clientRepo.CancelBookingWithRefund(ctx, s.config.ADMIN_EMAIL, booking, "cancelled", "Vendor Response Timeout")
+		if err != nil {
+			s.log.Error("Failed to cancel unanswered booking: %v", err)
+			continue
+		}
+		if !cancelled {
+			continue
+		}
+
+		s.log.Info("Cancelled unanswered booking:", booking.BookingID.String())
+
+		s.notifyUser(ctx, booking.ClientID, "Booking cancelled",
+			fmt.Sprintf("The vendor did not respond to your %s booking in time. %d has been refunded to your wallet.", booking.Service, booking.Price))
+		s.notifyUser(ctx, booking.VendorID, "Booking expired",
+			fmt.Sprintf("The %s booking was cancelled because it was not accepted in time.", booking.Service))
+	}
+
+	return nil
+}
+
+func (s *ClientService) AddToCart(ctx context.Context, req *pb.AddToCartRequest) (*pb.AddToCartResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	vendorUUID, err := uuid.Parse(req.GetVendorId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse vendor_id")
+	}
+
+	serviceUUID, err := uuid.Parse(req.GetServiceId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse service_id")
+	}
+
+	serviceExists, err := s.clientRepo.ServiceExists(ctx, req.GetVendorId(), req.GetServiceId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check service exists :%v", err)
+	}
+
+	if !serviceExists {
+		return nil, status.Errorf(codes.NotFound, "service with ID %s does not exists", req.GetServiceId())
+	}
+
+	inCart, err := s.clientRepo.CartItemExists(ctx, req.GetClientId(), req.GetServiceId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check cart: %v", err)
+	}
+
+	if inCart {
+		return nil, status.Errorf(codes.AlreadyExists, "service is already in the cart")
+	}
+
+	item := &models.CartItem{
+		CartItemID: uuid.New(),
+		ClientID:   clientUUID,
+		VendorID:   vendorUUID,
+		ServiceID:  serviceUUID,
+	}
+
+	if err := s.clientRepo.AddCartItem(ctx, item); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add service to cart: %v", err)
+	}
+
+	return &pb.AddToCartResponse{
+		CartItemId: item.CartItemID.String(),
+		Message:    "Service added to cart",
+	}, nil
+}
+
+func (s *ClientService) RemoveFromCart(ctx context.Context, req *pb.RemoveFromCartRequest) (*pb.RemoveFromCartResponse, error) {
+	err := s.clientRepo.DeleteCartItem(ctx, req.GetClientId(), req.GetCartItemId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove service from cart: %v", err)
+	}
+
+	return &pb.RemoveFromCartResponse{
+		Message: "Service removed from cart",
+	}, nil
+}
+
+func (s *ClientService) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.GetCartResponse, error) {
+	items, err := s.clientRepo.GetCartItems(ctx, req.GetClientId())
+	if err != nil {
+		s.log.Error("Failed to fetch cart: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to fetch cart: %v", err)
+	}
+
+	var total int
+	var itemList []*pb.CartItem
+	for _, item := range items {
+		total += item.ServicePrice
+		itemList = append(itemList, &pb.CartItem{
+			CartItemId:   item.CartItemID.String(),
+			VendorId:     item.VendorID.String(),
+			VendorName:   item.FirstName + " " + item.LastName,
+			ServiceId:    item.ServiceID.String(),
+			ServiceTitle: item.ServiceTitle,
+			Price:        int32(item.ServicePrice),
+			Date:         timestamppb.New(item.AvailableDate),
+		})
+	}
+
+	return &pb.GetCartResponse{
+		Items: itemList,
+		Total: int32(total),
+	}, nil
+}
+
+func (s *ClientService) CheckoutCart(ctx context.Context, req *pb.CheckoutCartRequest) (*pb.CheckoutCartResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	items, err := s.clientRepo.GetCartItems(ctx, req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch cart: %v", err)
+	}
+
+	if len(items) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "cart is empty")
+	}
+
+	if err := s.enforceBookingPolicy(ctx, req.GetClientId(), "vendor_booking", "", len(items)); err != nil {
+		return nil, err
+	}
+
+	order := &models.Order{
+		OrderID:  uuid.New(),
+		ClientID: clientUUID,
+		Status:   "pending",
+	}
+
+	var lineItems []*stripe.CheckoutSessionLineItemParams
+	slots := make(map[string]bool)
+	for _, item := range items {
+		slot := item.VendorID.String() + item.AvailableDate.String()
+		if slots[slot] {
+			return nil, status.Errorf(codes.InvalidArgument, "cart has more than one service from the same vendor on %s", item.AvailableDate.Format("2006-01-02"))
+		}
+		slots[slot] = true
+
+		slotBooked, err := s.clientRepo.IsVendorSlotBooked(ctx, item.VendorID.String(), item.AvailableDate)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check vendor availability: %v", err)
+		}
+
+		if slotBooked {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s is no longer available on %s", item.ServiceTitle, item.AvailableDate.Format("2006-01-02"))
+		}
+
+		order.Amount += item.ServicePrice
+		order.Items = append(order.Items, models.OrderItem{
+			OrderItemID:  uuid.New(),
+			OrderID:      order.OrderID,
+			VendorID:     item.VendorID,
+			ServiceID:    item.ServiceID,
+			ServiceTitle: item.ServiceTitle,
+			Date:         item.AvailableDate,
+			Price:        item.ServicePrice,
+			Status:       "pending",
+		})
+
+		lineItems = append(lineItems, &stripe.CheckoutSessionLineItemParams{
+			PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+				Currency: stripe.String("inr"),
+				ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+					Name: stripe.String(item.ServiceTitle),
+				},
+				UnitAmount: stripe.Int64(int64(item.ServicePrice) * 100),
+			},
+			Quantity: stripe.Int64(1),
+		})
+	}
+
+	if err := s.clientRepo.CreateOrder(ctx, order); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+	}
+
+	sessionParams := &stripe.CheckoutSessionParams{
+		PaymentMethodTypes: stripe.StringSlice([]string{"card"}),
+		LineItems:          lineItems,
+		Mode:               stripe.String(string(stripe.CheckoutSessionModePayment)),
+		SuccessURL:         stripe.String(fmt.Sprintf("%s&purpose=%s", s.config.STRIPE_SUCCESS_URL, "cart_booking")),
+		CancelURL:          stripe.String(s.config.STRIPE_CANCEL_URL),
+		ClientReferenceID:  stripe.String(req.GetClientId()),
+		Metadata: map[string]string{
+			"user_id":  req.GetClientId(),
+			"order_id": order.OrderID.String(),
+		},
+	}
+
+	stripeSession, err := session.New(sessionParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CheckoutCartResponse{
+		Url:     stripeSession.URL,
+		OrderId: order.OrderID.String(),
+	}, nil
+}
+
+func (s *ClientService) bookOrderItem(ctx context.Context, clientID uuid.UUID, item *models.OrderItem) error {
+	slotBooked, err := s.clientRepo.IsVendorSlotBooked(ctx, item.VendorID.String(), item.Date)
+	if err != nil {
+		return err
+	}
+
+	if slotBooked {
+		return fmt.Errorf("vendor is already booked on %s", item.Date.Format("2006-01-02"))
+	}
+
+	newBooking := &adminModel.Booking{
+		BookingID: uuid.New(),
+		ClientID:  clientID,
+		VendorID:  item.VendorID,
+		Service:   item.ServiceTitle,
+		Date:      item.Date,
+		Status:    "pending",
+		Price:     item.Price,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.clientRepo.CreateBooking(ctx, newBooking); err != nil {
+		return err
+	}
+
+	if err := s.startVendorResponseWindow(ctx, newBooking); err != nil {
+		if err := s.clientRepo.UpdateBookingStatus(ctx, newBooking.BookingID.String(), "cancelled"); err != nil {
+			s.log.Error("Failed to cancel booking without response window:", newBooking.BookingID.String(), err)
+		}
+		return fmt.Errorf("failed to start vendor response window: %w", err)
+	}
+
+	item.Status = "booked"
+	item.BookingID = &newBooking.BookingID
+
+	s.recordBookingCategory(ctx, newBooking, "")
+
+	return nil
+}
+
+func (s *ClientService) bookingLocation() *time.Location {
+	if s.config.BOOKING_TIMEZONE == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(s.config.BOOKING_TIMEZONE)
+	if err != nil {
+		s.log.Error("Invalid booking timezone, falling back to UTC:", s.config.BOOKING_TIMEZONE)
+		return time.UTC
+	}
+	return loc
+}
+
+func (s *ClientService) bookingUsage(ctx context.Context, clientID, serviceType, eventID string, now time.Time) (policy.Usage, error) {
+	var usage policy.Usage
+	var err error
+
+	loc := s.bookingLocation()
+	dayStart := policy.DayStart(now, loc)
+	weekStart := policy.WeekStart(now, loc)
+
+	if serviceType == "event_booking" {
+		if usage.Today, err = s.clientRepo.CountTicketsSince(ctx, clientID, dayStart); err != nil {
+			return usage, err
+		}
+		if usage.ThisWeek, err = s.clientRepo.CountTicketsSince(ctx, clientID, weekStart); err != nil {
+			return usage, err
+		}
+		if eventID != "" {
+			if usage.EventTickets, err = s.clientRepo.CountClientEventTickets(ctx, clientID, eventID); err != nil {
+				return usage, err
+			}
+		}
+		usage.LastCancellation, err = s.clientRepo.GetLastCancellationTime(ctx, clientID, []string{"Cancel Event Booking"})
+		return usage, err
+	}
+
+	if usage.Today, err = s.clientRepo.CountBookingsSince(ctx, clientID, dayStart); err != nil {
+		return usage, err
+	}
+	if usage.ThisWeek, err = s.clientRepo.CountBookingsSince(ctx, clientID, weekStart); err != nil {
+		return usage, err
+	}
+	if usage.Pending, err = s.clientRepo.CountPendingBookings(ctx, clientID); err != nil {
+		return usage, err
+	}
+	usage.LastCancellation, err = s.clientRepo.GetLastCancellationTime(ctx, clientID, []string{"Cancel Vendor Booking"})
+	return usage, err
+}
+
+func (s *ClientService) enforceBookingPolicy(ctx context.Context, clientID, serviceType, eventID string, quantity int) error {
+	policies, err := s.clientRepo.GetBookingPolicies(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch booking policies: %v", err)
+	}
+
+	isMasterOfCeremony, err := s.clientRepo.IsMaterofCeremony(ctx, clientID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check isMasterOfCeremony %v", err)
+	}
+
+	tier := "standard"
+	if isMasterOfCeremony {
+		tier = "master_of_ceremony"
+	}
+
+	now := time.Now()
+	usage, err := s.bookingUsage(ctx, clientID, serviceType, eventID, now)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check booking usage: %v", err)
+	}
+
+	rule := policy.Resolve(policies, tier, serviceType)
+	violations := policy.Evaluate(rule, usage, quantity, now)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	quotaFailure := &errdetails.QuotaFailure{}
+	for _, v := range violations {
+		quotaFailure.Violations = append(quotaFailure.Violations, &errdetails.QuotaFailure_Violation{
+			Subject:     v.Rule,
+			Description: v.Description,
+		})
+	}
+
+	st := status.New(codes.PermissionDenied, "Booking limit reached: "+violations[0].Description)
+	detailed, err := st.WithDetails(quotaFailure)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// refundIfOverBookingLimit re-checks the booking limits once a payment has
+// completed, since other checkouts may have finished while this one was open.
+// When the limits are exceeded the payment is recorded and goes back to the
+// client's wallet in one step, so it must run before the payment is recorded.
+// It also reports true when an earlier delivery already handled the payment.
+func (s *ClientService) refundIfOverBookingLimit(ctx context.Context, payment *models.Transaction, serviceType, eventID string, quantity int, item string) (bool, error) {
+	err := s.enforceBookingPolicy(ctx, payment.UserID.String(), serviceType, eventID, quantity)
+	if err == nil {
+		return false, nil
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		return false, err
+	}
+
+	refunded, refundErr := s.clientRepo.RecordRefundedPayment(ctx, s.config.ADMIN_EMAIL, payment, "Booking Limit Refund")
+	if refundErr != nil {
+		return false, status.Errorf(codes.Internal, "failed to refund booking over limit: %v", refundErr)
+	}
+
+	if refunded {
+		s.notifyUser(ctx, payment.UserID, "Booking limit reached",
+			fmt.Sprintf("Your %s booking went over your booking limit (%s). %d has been refunded to your wallet.", item, status.Convert(err).Message(), payment.AmountPaid))
+	}
+	return true, nil
+}
+
+func (s *ClientService) SetBookingPolicy(ctx context.Context, req *pb.SetBookingPolicyRequest) (*pb.SetBookingPolicyResponse, error) {
+	isAdmin, err := s.clientRepo.IsAdmin(ctx, req.GetAdminId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify admin: %v", err)
+	}
+	if !isAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can set booking policies")
+	}
+
+	switch req.GetTier() {
+	case policy.Any, "standard", "master_of_ceremony":
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "tier must be one of *, standard or master_of_ceremony")
+	}
+
+	switch req.GetServiceType() {
+	case policy.Any, "vendor_booking", "event_booking":
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "service_type must be one of *, vendor_booking or event_booking")
+	}
+
+	if req.GetDailyLimit() < 0 || req.GetWeeklyLimit() < 0 || req.GetMaxPending() < 0 ||
+		req.GetMaxTicketsPerEvent() < 0 || req.GetCancellationCooldownHours() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "policy limits cannot be negative")
+	}
+
+	bookingPolicy := &models.BookingPolicy{
+		Tier:                      req.GetTier(),
+		ServiceType:               req.GetServiceType(),
+		DailyLimit:                int(req.GetDailyLimit()),
+		WeeklyLimit:               int(req.GetWeeklyLimit()),
+		MaxPending:                int(req.GetMaxPending()),
+		MaxTicketsPerEvent:        int(req.GetMaxTicketsPerEvent()),
+		CancellationCooldownHours: int(req.GetCancellationCooldownHours()),
+	}
+
+	if err := s.clientRepo.SetBookingPolicy(ctx, bookingPolicy); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save booking policy: %v", err)
+	}
+
+	return &pb.SetBookingPolicyResponse{
+		Message: "Booking policy saved",
+	}, nil
+}
+
+const eventCancellationBatchSize = 100
+
+func (s *ClientService) CancelHostedEvent(ctx context.Context, req *pb.CancelHostedEventRequest) (*pb.CancelHostedEventResponse, error) {
+	hostUUID, err := uuid.Parse(req.GetHostId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse host_id")
+	}
+
+	refundTo := req.GetRefundTo()
+	if refundTo == "" {
+		refundTo = "wallet"
+	}
+	if refundTo != "wallet" && refundTo != "source" {
+		return nil, status.Errorf(codes.InvalidArgument, "refund_to must be wallet or source")
+	}
+
+	event, err := s.clientRepo.GetEventByID(ctx, req.GetEventId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "event not found: %v", err)
+	}
+
+	if event.HostedBy != hostUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "event is not hosted by the user")
+	}
+
+	if event.Status == "cancelled" {
+		return nil, status.Errorf(codes.FailedPrecondition, "event is already cancelled")
+	}
+
+	if err := s.checkEventRefundable(ctx, event); err != nil {
+		return nil, err
+	}
+
+	cancellation := &models.EventCancellation{
+		EventID:  event.EventID,
+		HostID:   hostUUID,
+		RefundTo: refundTo,
+		Reason:   req.GetReason(),
+		Status:   "in_progress",
+	}
+
+	cancelled, err := s.clientRepo.CancelEventWithCancellation(ctx, cancellation)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel event: %v", err)
+	}
+	if !cancelled {
+		return nil, status.Errorf(codes.FailedPrecondition, "event is already cancelled")
+	}
+
+	s.recordEventAudit(ctx, event.EventID, hostUUID, "event_cancelled", fmt.Sprintf("refund_to=%s reason=%q", refundTo, req.GetReason()))
+
+	go func() {
+		if err := s.processEventCancellation(context.Background(), cancellation); err != nil {
+			s.log.Error("Failed to process event cancellation:", event.EventID.String(), err)
+		}
+	}()
+
+	return &pb.CancelHostedEventResponse{
+		Message: "Event cancelled, ticket holders are being refunded",
+	}, nil
+}
+
+func (s *ClientService) ProcessEventCancellations(ctx context.Context) error {
+	cancellations, err := s.clientRepo.GetEventCancellationsInProgress(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event cancellations: %w", err)
+	}
+
+	for i := range cancellations {
+		if err := s.processEventCancellation(ctx, &cancellations[i]); err != nil {
+			s.log.Error("Failed to process event cancellation:", cancellations[i].EventID.String(), err)
+		}
+	}
+
+	return nil
+}
+
+const eventCancellationLease = 10 * time.Minute
+
+func (s *ClientService) processEventCancellation(ctx context.Context, cancellation *models.EventCancellation) error {
+	claimed, err := s.clientRepo.ClaimEventCancellation(ctx, cancellation.EventID, time.Now().Add(eventCancellationLease))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	eventID := cancellation.EventID.String()
+
+	eventName, err := s.clientRepo.GetEventNameByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	eventPrice, err := s.clientRepo.GetEventAmount(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	// Tickets that fail to refund are skipped for the rest of this run and
+	// retried by the scheduler on the next one.
+	var skip []string
+	failed := 0
+	for {
+		tickets, err := s.clientRepo.GetRefundableTickets(ctx, eventID, skip, eventCancellationBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(tickets) == 0 {
+			cancellation.Failed = failed
+			cancellation.ClaimedUntil = nil
+			if failed == 0 {
+				cancellation.Status = "completed"
+			}
+			return s.clientRepo.UpdateEventCancellationProgress(ctx, cancellation)
+		}
+
+		for i := range tickets {
+			ticket := &tickets[i]
+
+			amount := ticket.Amount
+			if amount == 0 {
+				amount = int(eventPrice)
+			}
+
+			ok, err := s.refundEventTicket(ctx, ticket, amount, cancellation.RefundTo)
+			if err != nil {
+				s.log.Error("Failed to refund ticket:", ticket.TicketID, err)
+				skip = append(skip, ticket.TicketID)
+				failed++
+				continue
+			}
+			if !ok {
+				skip = append(skip, ticket.TicketID)
+				continue
+			}
+
+			cancellation.Processed++
+			s.notifyUser(ctx, ticket.ClientID, "Event cancelled",
+				fmt.Sprintf("%s has been cancelled by the host. %d has been refunded for your ticket.", eventName, amount))
+		}
+
+		lease := time.Now().Add(eventCancellationLease)
+		cancellation.ClaimedUntil = &lease
+		cancellation.Failed = failed
+		if err := s.clientRepo.UpdateEventCancellationProgress(ctx, cancellation); err != nil {
+			return err
+		}
+	}
+}
+
+// checkEventRefundable rejects refunds once the host has been paid out or the
+// event is over. The repository repeats the check under a lock.
+func (s *ClientService) checkEventRefundable(ctx context.Context, event *models.Event) error {
+	settlement, err := s.clientRepo.GetEventSettlement(ctx, event.EventID.String())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch settlement: %v", err)
+	}
+	if settlement != nil {
+		return status.Errorf(codes.FailedPrecondition, "event has already been settled")
+	}
+
+	if event.Status != "cancelled" && time.Now().After(event.EndsAt) {
+		return status.Errorf(codes.FailedPrecondition, "event has already ended")
+	}
+	return nil
+}
+
+func (s *ClientService) refundEventTicket(ctx context.Context, ticket *models.Ticket, amount int, refundTo string) (bool, error) {
+	claimed, err := s.clientRepo.ClaimTicketRefund(ctx, ticket)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	method := "wallet"
+	if refundTo == "source" && ticket.PaymentIntentID != "" {
+		params := &stripe.RefundParams{
+			PaymentIntent: stripe.String(ticket.PaymentIntentID),
+			Amount:        stripe.Int64(int64(amount) * 100),
+		}
+		params.SetIdempotencyKey("ticket-refund-" + ticket.TicketID)
+
+		if _, err := refund.New(params); err != nil {
+			// A ticket that was already refunding may have been refunded by
+			// Stripe before, so it stays claimed for the next attempt.
+			if ticket.Status != "refunding" {
+				if err := s.clientRepo.ReleaseTicketRefund(ctx, ticket, ticket.Status); err != nil {
+					s.log.Error("Failed to release ticket refund:", ticket.TicketID, err)
+				}
+			}
+			return false, err
+		}
+		method = "stripe"
+	}
+
+	return s.clientRepo.RefundTicket(ctx, s.config.ADMIN_EMAIL, ticket, amount, "refunding", "refunded", method, "Event Cancelled Refund")
+}
+
+func (s *ClientService) newEventTicket(ctx context.Context, clientID, eventID uuid.UUID, tierID *uuid.UUID, amount int, paymentIntentID string) (*models.Ticket, *models.QR, error) {
+	ticketID := uuid.New()
+
+	newTicket := &models.Ticket{
+		ID:              ticketID,
+		TicketID:        ticketID.String(),
+		ClientID:        clientID,
+		EventID:         eventID,
+		TierID:          tierID,
+		Status:          "booked",
+		Amount:          amount,
+		PaymentIntentID: paymentIntentID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	newQR, err := s.newTicketQR(ctx, newTicket)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to sign QR code: %v", err)
+	}
+
+	return newTicket, newQR, nil
+}
+
+func selectTicketTier(tiers []models.TicketTier, tierID string, quantity int, now time.Time) (*models.TicketTier, error) {
+	if tierID == "" {
+		if len(tiers) != 1 {
+			return nil, status.Errorf(codes.InvalidArgument, "tier_id is required for this event")
+		}
+		tierID = tiers[0].ID.String()
+	}
+
+	for i := range tiers {
+		tier := &tiers[i]
+		if tier.ID.String() != tierID {
+			continue
+		}
+
+		if tier.SaleStart != nil && now.Before(*tier.SaleStart) {
+			return nil, status.Errorf(codes.FailedPrecondition, "sales for %s open at %s", tier.Name, tier.SaleStart.Format(time.RFC3339))
+		}
+		if tier.SaleEnd != nil && now.After(*tier.SaleEnd) {
+			return nil, status.Errorf(codes.FailedPrecondition, "sales for %s have ended", tier.Name)
+		}
+		if tier.PerOrderLimit > 0 && quantity > tier.PerOrderLimit {
+			return nil, status.Errorf(codes.InvalidArgument, "at most %d %s tickets can be bought per order", tier.PerOrderLimit, tier.Name)
+		}
+		if tier.Quantity-tier.Sold < quantity {
+			return nil, status.Errorf(codes.ResourceExhausted, "only %d %s tickets are left", tier.Quantity-tier.Sold, tier.Name)
+		}
+
+		return tier, nil
+	}
+
+	return nil, status.Errorf(codes.NotFound, "ticket tier %s not found for this event", tierID)
+}
+
+func ticketTiersFromRequest(eventID uuid.UUID, reqTiers []*pb.TicketTier, price, limit int) ([]models.TicketTier, error) {
+	if len(reqTiers) == 0 {
+		return []models.TicketTier{{
+			ID:       uuid.New(),
+			EventID:  eventID,
+			Name:     "General",
+			Price:    price,
+			Quantity: limit,
+		}}, nil
+	}
+
+	var tiers []models.TicketTier
+	for _, t := range reqTiers {
+		if t.GetName() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "ticket tier name is required")
+		}
+		if t.GetPrice() < 0 || t.GetQuantity() <= 0 || t.GetPerOrderLimit() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "ticket tier %s must have a non-negative price and a positive quantity", t.GetName())
+		}
+
+		tier := models.TicketTier{
+			ID:            uuid.New(),
+			EventID:       eventID,
+			Name:          t.GetName(),
+			Price:         int(t.GetPrice()),
+			Quantity:      int(t.GetQuantity()),
+			PerOrderLimit: int(t.GetPerOrderLimit()),
+		}
+
+		if t.GetTierId() != "" {
+			tierUUID, err := uuid.Parse(t.GetTierId())
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid tier_id %s", t.GetTierId())
+			}
+			tier.ID = tierUUID
+		}
+
+		if t.GetSaleStart() != nil {
+			start := t.GetSaleStart().AsTime()
+			tier.SaleStart = &start
+		}
+		if t.GetSaleEnd() != nil {
+			end := t.GetSaleEnd().AsTime()
+			tier.SaleEnd = &end
+		}
+		if tier.SaleStart != nil && tier.SaleEnd != nil && !tier.SaleEnd.After(*tier.SaleStart) {
+			return nil, status.Errorf(codes.InvalidArgument, "ticket tier %s sale end must be after sale start", t.GetName())
+		}
+
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, nil
+}
+
+func ticketTierSummary(tiers []models.TicketTier, now time.Time) ([]*pb.TicketTier, int32) {
+	var list []*pb.TicketTier
+	cheapest := int32(-1)
+
+	for _, tier := range tiers {
+		available := tier.Quantity - tier.Sold
+		onSale := (tier.SaleStart == nil || !now.Before(*tier.SaleStart)) &&
+			(tier.SaleEnd == nil || !now.After(*tier.SaleEnd))
+
+		pbTier := &pb.TicketTier{
+			TierId:        tier.ID.String(),
+			Name:          tier.Name,
+			Price:         int32(tier.Price),
+			Quantity:      int32(tier.Quantity),
+			Available:     int32(available),
+			PerOrderLimit: int32(tier.PerOrderLimit),
+			SoldOut:       available <= 0,
+			OnSale:        onSale,
+		}
+		if tier.SaleStart != nil {
+			pbTier.SaleStart = timestamppb.New(*tier.SaleStart)
+		}
+		if tier.SaleEnd != nil {
+			pbTier.SaleEnd = timestamppb.New(*tier.SaleEnd)
+		}
+		list = append(list, pbTier)
+
+		if onSale && available > 0 && (cheapest < 0 || int32(tier.Price) < cheapest) {
+			cheapest = int32(tier.Price)
+		}
+	}
+
+	return list, cheapest
+}
+
+func (s *ClientService) ticketTiersByEvent(ctx context.Context, events []models.Event) (map[uuid.UUID][]models.TicketTier, error) {
+	var eventIDs []uuid.UUID
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+
+	tiers, err := s.clientRepo.GetTicketTiersByEventIDs(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tiersMap := make(map[uuid.UUID][]models.TicketTier)
+	for _, tier := range tiers {
+		tiersMap[tier.EventID] = append(tiersMap[tier.EventID], tier)
+	}
+	return tiersMap, nil
+}
+
+func (s *ClientService) updateTicketTiers(ctx context.Context, eventID, actorID uuid.UUID, reqTiers []*pb.TicketTier) error {
+	tiers, err := ticketTiersFromRequest(eventID, reqTiers, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.clientRepo.GetTicketTiersByEventIDs(ctx, []uuid.UUID{eventID})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	existingMap := make(map[uuid.UUID]models.TicketTier)
+	for _, tier := range existing {
+		existingMap[tier.ID] = tier
+	}
+
+	kept := make(map[uuid.UUID]bool)
+	for i, t := range reqTiers {
+		if t.GetTierId() == "" {
+			continue
+		}
+
+		current, ok := existingMap[tiers[i].ID]
+		if !ok {
+			return status.Errorf(codes.NotFound, "ticket tier %s does not belong to this event", t.GetTierId())
+		}
+
+		if tiers[i].Quantity < current.Sold {
+			return status.Errorf(codes.FailedPrecondition, "ticket tier %s already sold %d tickets", current.Name, current.Sold)
+		}
+
+		if current.Sold > 0 && tiers[i].Price != current.Price {
+			return status.Errorf(codes.FailedPrecondition, "price of ticket tier %s cannot change after sales have started", current.Name)
+		}
+
+		tiers[i].Sold = current.Sold
+		tiers[i].CreatedAt = current.CreatedAt
+		kept[current.ID] = true
+	}
+
+	var changes []string
+	for _, tier := range tiers {
+		current, ok := existingMap[tier.ID]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("added %q price=%d quantity=%d", tier.Name, tier.Price, tier.Quantity))
+			continue
+		}
+		if change := tierChange(current, tier); change != "" {
+			changes = append(changes, change)
+		}
+	}
+
+	var removeIDs []uuid.UUID
+	for _, tier := range existing {
+		if kept[tier.ID] {
+			continue
+		}
+		if tier.Sold > 0 {
+			return status.Errorf(codes.FailedPrecondition, "ticket tier %s has sales and cannot be removed", tier.Name)
+		}
+		removeIDs = append(removeIDs, tier.ID)
+		changes = append(changes, fmt.Sprintf("removed %q", tier.Name))
+	}
+
+	if err := s.clientRepo.SaveTicketTiers(ctx, eventID, tiers, removeIDs); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return err
+		}
+		return status.Errorf(codes.Internal, "failed to update ticket tiers: %v", err)
+	}
+
+	if len(changes) > 0 {
+		s.recordEventAudit(ctx, eventID, actorID, "ticket_tiers_updated", strings.Join(changes, "; "))
+	}
+
+	return nil
+}
+
+// tierChange describes a price or capacity change for the audit log.
+func tierChange(before, after models.TicketTier) string {
+	var parts []string
+	if before.Price != after.Price {
+		parts = append(parts, fmt.Sprintf("price=%d->%d", before.Price, after.Price))
+	}
+	if before.Quantity != after.Quantity {
+		parts = append(parts, fmt.Sprintf("quantity=%d->%d", before.Quantity, after.Quantity))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%q %s", after.Name, strings.Join(parts, " "))
+}
+
+func (s *ClientService) authorizeEventScanner(ctx context.Context, eventID, scannerID string) (*models.Event, uuid.UUID, error) {
+	return s.authorizeEventAccess(ctx, eventID, scannerID, permCheckIn)
+}
+
+func (s *ClientService) resolveEventTicket(ctx context.Context, eventID uuid.UUID, payload string) (*models.Ticket, error) {
+	code := strings.TrimSpace(payload)
+	if !qrsign.IsSigned(code) {
+		return nil, status.Errorf(codes.InvalidArgument, "ticket must be presented with its signed QR code")
+	}
+
+	return s.resolveSignedTicket(ctx, eventID, code)
+}
+
+func (s *ClientService) resolveSignedTicket(ctx context.Context, eventID uuid.UUID, code string) (*models.Ticket, error) {
+	keys, err := s.qrVerificationKeys(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load verification keys: %v", err)
+	}
+
+	payload, err := qrsign.Verify(code, keys)
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+
+	if payload.EventID != eventID.String() {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is for a different event")
+	}
+
+	qr, err := s.clientRepo.GetQRByPayload(ctx, code)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "QR code not recognised: %v", err)
+	}
+
+	if qr.IsVoid {
+		return nil, status.Errorf(codes.FailedPrecondition, "QR code has been revoked")
+	}
+
+	ticket, err := s.clientRepo.GetTicketByTicketID(ctx, payload.TicketID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "ticket not found: %v", err)
+	}
+
+	if ticket.ClientID.String() != payload.HolderID {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket holder has changed, QR code is no longer valid")
+	}
+
+	return ticket, nil
+}
+
+func (s *ClientService) CheckInTicket(ctx context.Context, req *pb.CheckInTicketRequest) (*pb.CheckInTicketResponse, error) {
+	event, scannerUUID, err := s.authorizeEventScanner(ctx, req.GetEventId(), req.GetScannerId())
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status == "cancelled" {
+		return nil, status.Errorf(codes.FailedPrecondition, "event has been cancelled")
+	}
+
+	ticket, err := s.resolveEventTicket(ctx, event.EventID, req.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.Status != "booked" {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is %s", ticket.Status)
+	}
+
+	scannedAt := time.Now()
+	ok, err := s.clientRepo.MarkTicketScanned(ctx, ticket.ID, scannerUUID, req.GetGate(), scannedAt)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check in ticket: %v", err)
+	}
+
+	if !ok {
+		qr, err := s.clientRepo.GetQRByTicketID(ctx, ticket.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "no QR code issued for this ticket: %v", err)
+		}
+		if qr.IsVoid {
+			return nil, status.Errorf(codes.FailedPrecondition, "QR code has been invalidated")
+		}
+		if qr.ScannedAt != nil {
+			return nil, status.Errorf(codes.AlreadyExists, "ticket already checked in at %s", qr.ScannedAt.Format(time.RFC3339))
+		}
+		return nil, status.Errorf(codes.AlreadyExists, "ticket already checked in")
+	}
+
+	attendeeName := ""
+	if details, err := s.clientRepo.GetUserDetailsByID(ctx, ticket.ClientID.String()); err == nil {
+		attendeeName = details.FirstName + " " + details.LastName
+	}
+
+	tierName := ""
+	if ticket.TierID != nil {
+		if tier, err := s.clientRepo.GetTicketTierByID(ctx, ticket.TierID.String()); err == nil {
+			tierName = tier.Name
+		}
+	}
+
+	s.recordEventAudit(ctx, event.EventID, scannerUUID, "ticket_checked_in",
+		fmt.Sprintf("ticket %s at gate %q", ticket.TicketID, req.GetGate()))
+
+	checkedIn, total, err := s.clientRepo.CountEventCheckIns(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count check-ins: %v", err)
+	}
+
+	return &pb.CheckInTicketResponse{
+		TicketId:     ticket.TicketID,
+		AttendeeName: attendeeName,
+		TierName:     tierName,
+		ScannedAt:    timestamppb.New(scannedAt),
+		CheckedIn:    int32(checkedIn),
+		TotalTickets: int32(total),
+	}, nil
+}
+
+func (s *ClientService) UndoCheckIn(ctx context.Context, req *pb.UndoCheckInRequest) (*pb.UndoCheckInResponse, error) {
+	event, scannerUUID, err := s.authorizeEventScanner(ctx, req.GetEventId(), req.GetScannerId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Undo is driven from the check-in list, which carries plain ticket ids
+	// rather than signed QR payloads.
+	ticket, err := s.clientRepo.GetTicketByTicketID(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "ticket not found: %v", err)
+	}
+
+	if ticket.EventID != event.EventID {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is for a different event")
+	}
+
+	ok, err := s.clientRepo.UndoTicketScan(ctx, ticket.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to undo check-in: %v", err)
+	}
+
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket has not been checked in")
+	}
+
+	s.recordEventAudit(ctx, event.EventID, scannerUUID, "check_in_undone", "ticket "+ticket.TicketID)
+
+	checkedIn, total, err := s.clientRepo.CountEventCheckIns(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count check-ins: %v", err)
+	}
+
+	return &pb.UndoCheckInResponse{
+		Message:      "Check-in reverted",
+		CheckedIn:    int32(checkedIn),
+		TotalTickets: int32(total),
+	}, nil
+}
+
+func (s *ClientService) GetCheckInCount(ctx context.Context, req *pb.GetCheckInCountRequest) (*pb.GetCheckInCountResponse, error) {
+	event, _, err := s.authorizeEventScanner(ctx, req.GetEventId(), req.GetScannerId())
+	if err != nil {
+		return nil, err
+	}
+
+	checkedIn, total, err := s.clientRepo.CountEventCheckIns(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count check-ins: %v", err)
+	}
+
+	return &pb.GetCheckInCountResponse{
+		CheckedIn:    int32(checkedIn),
+		TotalTickets: int32(total),
+	}, nil
+}
+
+const defaultQRKeyRotationDays = 30
+
+func (s *ClientService) RotateQRSigningKey(ctx context.Context) error {
+	active, err := s.clientRepo.GetActiveQRSigningKey(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch active signing key: %w", err)
+	}
+
+	rotationDays := s.config.QR_KEY_ROTATION_DAYS
+	if rotationDays <= 0 {
+		rotationDays = defaultQRKeyRotationDays
+	}
+
+	if active != nil && time.Since(active.CreatedAt) < time.Duration(rotationDays)*24*time.Hour {
+		return nil
+	}
+
+	_, err = s.createQRSigningKey(ctx)
+	return err
+}
+
+func (s *ClientService) createQRSigningKey(ctx context.Context) (*models.QRSigningKey, error) {
+	keyID, pub, priv, err := qrsign.GenerateKey(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	sealed, err := qrsign.SealPrivateKey(s.config.QR_KEY_SECRET, priv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal signing key: %w", err)
+	}
+
+	key := &models.QRSigningKey{
+		KeyID:      keyID,
+		PublicKey:  qrsign.EncodePublicKey(pub),
+		PrivateKey: sealed,
+	}
+
+	if err := s.clientRepo.RotateQRSigningKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to store signing key: %w", err)
+	}
+
+	s.log.Info("Rotated QR signing key:", keyID)
+	return key, nil
+}
+
+func (s *ClientService) qrVerificationKeys(ctx context.Context) (map[string]ed25519.PublicKey, error) {
+	keys, err := s.clientRepo.GetQRSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKeys := make(map[string]ed25519.PublicKey)
+	for _, key := range keys {
+		pub, err := qrsign.DecodePublicKey(key.PublicKey)
+		if err != nil {
+			s.log.Error("Skipping invalid QR verification key:", key.KeyID, err)
+			continue
+		}
+		verificationKeys[key.KeyID] = pub
+	}
+	return verificationKeys, nil
+}
+
+func (s *ClientService) activeSigningKey(ctx context.Context) (*models.QRSigningKey, ed25519.PrivateKey, error) {
+	key, err := s.clientRepo.GetActiveQRSigningKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if key == nil {
+		key, err = s.createQRSigningKey(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	priv, err := qrsign.OpenPrivateKey(s.config.QR_KEY_SECRET, key.PrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, priv, nil
+}
+
+func (s *ClientService) newTicketQR(ctx context.Context, ticket *models.Ticket) (*models.QR, error) {
+	key, priv, err := s.activeSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payload, err := qrsign.Sign(qrsign.Payload{
+		TicketID: ticket.TicketID,
+		EventID:  ticket.EventID.String(),
+		HolderID: ticket.ClientID.String(),
+		IssuedAt: now.Unix(),
+		KeyID:    key.KeyID,
+	}, priv)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketID := ticket.ID
+	return &models.QR{
+		ID:          uuid.New(),
+		UserID:      ticket.ClientID,
+		EventID:     ticket.EventID,
+		TicketID:    &ticketID,
+		Code:        utils.GenerateQRCode(payload),
+		Payload:     payload,
+		GeneratedAt: now,
+		IsScanned:   false,
+	}, nil
+}
+
+func (s *ClientService) reissueTicketQR(ctx context.Context, ticket *models.Ticket) (*models.QR, error) {
+	qr, err := s.newTicketQR(ctx, ticket)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign QR code: %v", err)
+	}
+
+	if err := s.clientRepo.ReplaceTicketQR(ctx, ticket.ID, qr); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reissue QR code: %v", err)
+	}
+
+	return qr, nil
+}
+
+const legacyQRBatchSize = 100
+
+// SignLegacyTicketQRs replaces the unsigned QR codes of tickets booked before
+// signing was introduced, since check-in only accepts signed codes.
+func (s *ClientService) SignLegacyTicketQRs(ctx context.Context) error {
+	for {
+		tickets, err := s.clientRepo.GetTicketsWithUnsignedQR(ctx, legacyQRBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to fetch tickets with unsigned QR codes: %w", err)
+		}
+
+		if len(tickets) == 0 {
+			return nil
+		}
+
+		for i := range tickets {
+			ticket := &tickets[i]
+			if _, err := s.reissueTicketQR(ctx, ticket); err != nil {
+				return fmt.Errorf("failed to sign QR code for ticket %s: %w", ticket.TicketID, err)
+			}
+
+			s.notifyUser(ctx, ticket.ClientID, "Ticket QR code updated",
+				"Your ticket has a new QR code. Please use the latest code from your tickets at the door.")
+		}
+	}
+}
+
+func (s *ClientService) ReissueTicketQR(ctx context.Context, req *pb.ReissueTicketQRRequest) (*pb.ReissueTicketQRResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	ticket, err := s.clientRepo.GetTicketByTicketID(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "ticket not found: %v", err)
+	}
+
+	if ticket.ClientID != clientUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "ticket does not belong to the user")
+	}
+
+	if ticket.Status != "booked" {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is %s", ticket.Status)
+	}
+
+	if current, err := s.clientRepo.GetQRByTicketID(ctx, ticket.ID); err == nil && current.IsScanned {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket has already been checked in")
+	}
+
+	qr, err := s.reissueTicketQR(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Reissued QR code for ticket:", ticket.TicketID, req.GetReason())
+
+	return &pb.ReissueTicketQRResponse{
+		Message: "QR code reissued, the previous code no longer works",
+		QrCode:  qr.Code,
+	}, nil
+}
+
+func (s *ClientService) GetQRVerificationKeys(ctx context.Context, req *pb.GetQRVerificationKeysRequest) (*pb.GetQRVerificationKeysResponse, error) {
+	keyList, err := s.publicVerificationKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetQRVerificationKeysResponse{
+		Keys: keyList,
+	}, nil
+}
+
+func (s *ClientService) publicVerificationKeys(ctx context.Context) ([]*pb.QRVerificationKey, error) {
+	keys, err := s.clientRepo.GetQRSigningKeys(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch verification keys: %v", err)
+	}
+
+	var keyList []*pb.QRVerificationKey
+	for _, key := range keys {
+		keyList = append(keyList, &pb.QRVerificationKey{
+			KeyId:     key.KeyID,
+			PublicKey: key.PublicKey,
+			Status:    key.Status,
+			CreatedAt: timestamppb.New(key.CreatedAt),
+		})
+	}
+	return keyList, nil
+}
+
+type manifestEntry struct {
+	TicketID   string `json:"ticket_id"`
+	HolderName string `json:"holder_name"`
+	TierName   string `json:"tier_name"`
+	Status     string `json:"status"`
+	CheckedIn  bool   `json:"checked_in"`
+}
+
+type checkInManifest struct {
+	EventID     string          `json:"event_id"`
+	GeneratedAt int64           `json:"generated_at"`
+	Entries     []manifestEntry `json:"entries"`
+}
+
+func (s *ClientService) GetCheckInManifest(ctx context.Context, req *pb.GetCheckInManifestRequest) (*pb.GetCheckInManifestResponse, error) {
+	event, _, err := s.authorizeEventScanner(ctx, req.GetEventId(), req.GetScannerId())
+	if err != nil {
+		return nil, err
+	}
+
+	holders, err := s.clientRepo.GetEventTicketHolders(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket holders: %v", err)
+	}
+
+	now := time.Now()
+	manifest := checkInManifest{
+		EventID:     event.EventID.String(),
+		GeneratedAt: now.Unix(),
+	}
+
+	var entries []*pb.ManifestEntry
+	for _, holder := range holders {
+		entry := manifestEntry{
+			TicketID:   holder.TicketID,
+			HolderName: strings.TrimSpace(holder.FirstName + " " + holder.LastName),
+			TierName:   holder.TierName,
+			Status:     holder.Status,
+			CheckedIn:  holder.IsScanned,
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+
+		entries = append(entries, &pb.ManifestEntry{
+			TicketId:   entry.TicketID,
+			HolderName: entry.HolderName,
+			TierName:   entry.TierName,
+			Status:     entry.Status,
+			CheckedIn:  entry.CheckedIn,
+		})
+	}
+
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode manifest: %v", err)
+	}
+
+	key, priv, err := s.activeSigningKey(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load signing key: %v", err)
+	}
+
+	keys, err := s.publicVerificationKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetCheckInManifestResponse{
+		EventId:          event.EventID.String(),
+		GeneratedAt:      timestamppb.New(now),
+		Entries:          entries,
+		Manifest:         string(body),
+		Signature:        qrsign.SignBytes(body, priv),
+		KeyId:            key.KeyID,
+		VerificationKeys: keys,
+	}, nil
+}
+
+func (s *ClientService) UploadScanBatch(ctx context.Context, req *pb.UploadScanBatchRequest) (*pb.UploadScanBatchResponse, error) {
+	event, scannerUUID, err := s.authorizeEventScanner(ctx, req.GetEventId(), req.GetScannerId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.UploadScanBatchResponse{}
+	for _, scan := range req.GetScans() {
+		if scan.GetScannedAt() == nil {
+			resp.Conflicts = append(resp.Conflicts, &pb.ScanConflict{
+				Payload: scan.GetPayload(),
+				Gate:    scan.GetGate(),
+				Reason:  "scanned_at is required",
+			})
+			continue
+		}
+		scannedAt := scan.GetScannedAt().AsTime()
+
+		ticket, err := s.resolveEventTicket(ctx, event.EventID, scan.GetPayload())
+		if err != nil {
+			resp.Conflicts = append(resp.Conflicts, &pb.ScanConflict{
+				Payload:   scan.GetPayload(),
+				Gate:      scan.GetGate(),
+				ScannedAt: scan.GetScannedAt(),
+				Reason:    status.Convert(err).Message(),
+			})
+			continue
+		}
+
+		duplicate, err := s.clientRepo.ScanRecordExists(ctx, ticket.ID, req.GetDeviceId(), scannedAt)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check scan record: %v", err)
+		}
+		if duplicate {
+			resp.Duplicates++
+			continue
+		}
+
+		result := "accepted"
+		conflict := &pb.ScanConflict{
+			Payload:   scan.GetPayload(),
+			TicketId:  ticket.TicketID,
+			Gate:      scan.GetGate(),
+			ScannedAt: scan.GetScannedAt(),
+		}
+
+		if ticket.Status != "booked" {
+			result = "rejected"
+			conflict.Reason = fmt.Sprintf("ticket is %s", ticket.Status)
+		} else {
+			ok, err := s.clientRepo.MergeOfflineScan(ctx, ticket.ID, scannerUUID, scan.GetGate(), scannedAt)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to merge scan: %v", err)
+			}
+
+			if !ok {
+				result = "conflict"
+				conflict.Reason = "ticket already checked in"
+				if qr, err := s.clientRepo.GetQRByTicketID(ctx, ticket.ID); err == nil {
+					if qr.IsVoid {
+						result = "rejected"
+						conflict.Reason = "QR code has been revoked"
+					}
+					conflict.ExistingGate = qr.ScanGate
+					if qr.ScannedAt != nil {
+						conflict.ExistingScannedAt = timestamppb.New(*qr.ScannedAt)
+					}
+				}
+			}
+		}
+
+		record := &models.ScanRecord{
+			EventID:   event.EventID,
+			TicketID:  ticket.ID,
+			ScannerID: scannerUUID,
+			DeviceID:  req.GetDeviceId(),
+			Gate:      scan.GetGate(),
+			ScannedAt: scannedAt,
+			Result:    result,
+		}
+		if err := s.clientRepo.CreateScanRecord(ctx, record); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to store scan record: %v", err)
+		}
+
+		if result == "accepted" {
+			resp.Accepted++
+			continue
+		}
+		resp.Conflicts = append(resp.Conflicts, conflict)
+	}
+
+	s.recordEventAudit(ctx, event.EventID, scannerUUID, "scan_batch_uploaded",
+		fmt.Sprintf("device %q: %d accepted, %d duplicates, %d conflicts", req.GetDeviceId(), resp.Accepted, resp.Duplicates, len(resp.Conflicts)))
+
+	checkedIn, total, err := s.clientRepo.CountEventCheckIns(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count check-ins: %v", err)
+	}
+
+	resp.CheckedIn = int32(checkedIn)
+	resp.TotalTickets = int32(total)
+	return resp, nil
+}
+
+func eventStartTime(event *models.Event, details *models.EventDetails) time.Time {
+	if !event.StartsAt.IsZero() {
+		return event.StartsAt
+	}
+	return time.Date(event.Date.Year(), event.Date.Month(), event.Date.Day(),
+		details.StartTime.Hour(), details.StartTime.Minute(), details.StartTime.Second(), 0, event.Date.Location())
+}
+
+func eventEndTime(event *models.Event, details *models.EventDetails) time.Time {
+	if !event.EndsAt.IsZero() {
+		return event.EndsAt
+	}
+	end := time.Date(event.Date.Year(), event.Date.Month(), event.Date.Day(),
+		details.EndTime.Hour(), details.EndTime.Minute(), details.EndTime.Second(), 0, event.Date.Location())
+	if end.Before(eventStartTime(event, details)) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+func (s *ClientService) checkTicketTransferable(ctx context.Context, ticket *models.Ticket) error {
+	if ticket.Status != "booked" {
+		return status.Errorf(codes.FailedPrecondition, "ticket is %s", ticket.Status)
+	}
+
+	if qr, err := s.clientRepo.GetQRByTicketID(ctx, ticket.ID); err == nil && qr.IsScanned {
+		return status.Errorf(codes.FailedPrecondition, "checked in tickets cannot be transferred")
+	}
+
+	event, err := s.clientRepo.GetEventByID(ctx, ticket.EventID.String())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch event: %v", err)
+	}
+
+	if event.Status == "cancelled" {
+		return status.Errorf(codes.FailedPrecondition, "tickets for cancelled events cannot be transferred")
+	}
+
+	details, err := s.clientRepo.GetEventDetailsByEventID(ctx, ticket.EventID.String())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
+	}
+
+	cutoff := eventStartTime(event, details).Add(-time.Duration(details.TransferCutoffHours) * time.Hour)
+	if time.Now().After(cutoff) {
+		return status.Errorf(codes.FailedPrecondition, "ticket transfers closed at %s", cutoff.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (s *ClientService) TransferTicket(ctx context.Context, req *pb.TransferTicketRequest) (*pb.TransferTicketResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	recipientUUID, err := uuid.Parse(req.GetRecipientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse recipient_id")
+	}
+
+	if recipientUUID == clientUUID {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot transfer a ticket to yourself")
+	}
+
+	if _, err := s.clientRepo.GetUserDetailsByID(ctx, recipientUUID.String()); err != nil {
+		return nil, status.Errorf(codes.NotFound, "recipient not found: %v", err)
+	}
+
+	ticket, err := s.clientRepo.GetTicketByTicketID(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "ticket not found: %v", err)
+	}
+
+	if ticket.ClientID != clientUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "ticket does not belong to the user")
+	}
+
+	if err := s.checkTicketTransferable(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.clientRepo.HasPendingTicketTransfer(ctx, ticket.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check pending transfers: %v", err)
+	}
+	if pending {
+		return nil, status.Errorf(codes.AlreadyExists, "ticket already has a pending transfer")
+	}
+
+	transfer := &models.TicketTransfer{
+		TicketID:     ticket.ID,
+		FromClientID: clientUUID,
+		ToClientID:   recipientUUID,
+		Status:       "pending",
+	}
+
+	if err := s.clientRepo.CreateTicketTransfer(ctx, transfer); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create ticket transfer: %v", err)
+	}
+
+	s.notifyUser(ctx, recipientUUID, "Ticket transfer",
+		fmt.Sprintf("You have been offered a ticket. Accept transfer %s to receive it.", transfer.ID))
+
+	return &pb.TransferTicketResponse{
+		Message:    "Transfer requested, waiting for the recipient to accept",
+		TransferId: transfer.ID.String(),
+	}, nil
+}
+
+func (s *ClientService) RespondToTicketTransfer(ctx context.Context, req *pb.RespondToTicketTransferRequest) (*pb.RespondToTicketTransferResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	transfer, err := s.clientRepo.GetTicketTransferByID(ctx, req.GetTransferId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "transfer not found: %v", err)
+	}
+
+	if transfer.ToClientID != clientUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "transfer is not addressed to the user")
+	}
+
+	if transfer.Status != "pending" {
+		return nil, status.Errorf(codes.FailedPrecondition, "transfer is already %s", transfer.Status)
+	}
+
+	if !req.GetAccept() {
+		if _, err := s.clientRepo.UpdateTicketTransferStatus(ctx, transfer.ID, "pending", "declined"); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decline transfer: %v", err)
+		}
+		s.notifyUser(ctx, transfer.FromClientID, "Ticket transfer declined", "The recipient declined your ticket transfer.")
+		return &pb.RespondToTicketTransferResponse{Message: "Transfer declined"}, nil
+	}
+
+	ticket, err := s.clientRepo.GetTicketByTicketID(ctx, transfer.TicketID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "ticket not found: %v", err)
+	}
+
+	if ticket.ClientID != transfer.FromClientID {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket owner has changed")
+	}
+
+	if err := s.checkTicketTransferable(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	ticket.ClientID = transfer.ToClientID
+	qr, err := s.newTicketQR(ctx, ticket)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign QR code: %v", err)
+	}
+
+	ok, err := s.clientRepo.AcceptTicketTransfer(ctx, transfer, qr)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to transfer ticket: %v", err)
+	}
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket can no longer be transferred")
+	}
+
+	s.notifyUser(ctx, transfer.FromClientID, "Ticket transferred", "Your ticket transfer was accepted. Your QR code is no longer valid.")
+
+	return &pb.RespondToTicketTransferResponse{
+		Message:  "Ticket transferred successfully",
+		TicketId: ticket.TicketID,
+		QrCode:   qr.Code,
+	}, nil
+}
+
+func (s *ClientService) CancelTicketTransfer(ctx context.Context, req *pb.CancelTicketTransferRequest) (*pb.CancelTicketTransferResponse, error) {
+	clientUUID, err := uuid.Parse(req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse client_id")
+	}
+
+	transfer, err := s.clientRepo.GetTicketTransferByID(ctx, req.GetTransferId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "transfer not found: %v", err)
+	}
+
+	if transfer.FromClientID != clientUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "transfer was not created by the user")
+	}
+
+	ok, err := s.clientRepo.UpdateTicketTransferStatus(ctx, transfer.ID, "pending", "cancelled")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cancel transfer: %v", err)
+	}
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "transfer is no longer pending")
+	}
+
+	return &pb.CancelTicketTransferResponse{Message: "Transfer cancelled"}, nil
+}
+
+func (s *ClientService) GetTicketTransfers(ctx context.Context, req *pb.GetTicketTransfersRequest) (*pb.GetTicketTransfersResponse, error) {
+	transfers, err := s.clientRepo.GetTicketTransfersByClientID(ctx, req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket transfers: %v", err)
+	}
+
+	var transferList []*pb.TicketTransfer
+	for _, transfer := range transfers {
+		transferList = append(transferList, &pb.TicketTransfer{
+			TransferId:   transfer.ID.String(),
+			TicketId:     transfer.TicketID.String(),
+			FromClientId: transfer.FromClientID.String(),
+			ToClientId:   transfer.ToClientID.String(),
+			Status:       transfer.Status,
+			CreatedAt:    timestamppb.New(transfer.CreatedAt),
+		})
+	}
+
+	return &pb.GetTicketTransfersResponse{
+		Transfers: transferList,
+	}, nil
+}
+
+func upcomingEventToPB(event models.Event, detail models.EventDetails, tiers []models.TicketTier, now time.Time) *pb.UpcomingEvent {
+	tierList, cheapest := ticketTierSummary(tiers, now)
+
+	return &pb.UpcomingEvent{
+		EventId: event.EventID.String(),
+		Title:   event.Title,
+		Location: &pb.Location{
+			Address:   event.Location.Address,
+			City:      event.Location.City,
+			Country:   event.Location.Country,
+			Latitude:  event.Location.Lat,
+			Longitude: event.Location.Lng,
+		},
+		Date:           timestamppb.New(event.Date),
+		Description:    detail.Description,
+		PosterImage:    detail.PosterImage,
+		PricePerTicket: int32(detail.PricePerTicket),
+		TicketLimit:    int32(detail.TicketLimit),
+		StartTime:      timestamppb.New(eventStartTime(&event, &detail)),
+		EndTime:        timestamppb.New(eventEndTime(&event, &detail)),
+		Timezone:       event.Timezone,
+		LocalStartTime: localEventTime(&event, eventStartTime(&event, &detail)),
+		LocalEndTime:   localEventTime(&event, eventEndTime(&event, &detail)),
+		TicketTiers:    tierList,
+		CheapestPrice:  cheapest,
+		SeriesId:       optionalUUID(event.SeriesID),
+		VenueId:        optionalUUID(event.VenueID),
+	}
+}
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+type searchCursor struct {
+	SortBy  string    `json:"s"`
+	EventID string    `json:"id"`
+	Date    time.Time `json:"d,omitempty"`
+	Number  int       `json:"n,omitempty"`
+}
+
+func encodeSearchCursor(sortBy string, event models.Event, detail models.EventDetails) string {
+	cursor := searchCursor{SortBy: sortBy, EventID: event.EventID.String()}
+	switch sortBy {
+	case "price":
+		cursor.Number = detail.PricePerTicket
+	case "popularity":
+		cursor.Number = detail.TicketsSold
+	default:
+		cursor.Date = event.StartsAt
+	}
+
+	body, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+func applySearchCursor(filter *repository.EventSearchFilter, encoded string) error {
+	body, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	var cursor searchCursor
+	if err := json.Unmarshal(body, &cursor); err != nil {
+		return err
+	}
+
+	if cursor.SortBy != filter.SortBy {
+		return fmt.Errorf("cursor was issued for sort_by %q", cursor.SortBy)
+	}
+
+	eventID, err := uuid.Parse(cursor.EventID)
+	if err != nil {
+		return err
+	}
+
+	filter.AfterEventID = &eventID
+	filter.AfterDate = cursor.Date
+	filter.AfterNumber = cursor.Number
+	return nil
+}
+
+func (s *ClientService) SearchEvents(ctx context.Context, req *pb.SearchEventsRequest) (*pb.SearchEventsResponse, error) {
+	sortBy := req.GetSortBy()
+	if sortBy == "" {
+		sortBy = "date"
+	}
+	if sortBy != "date" && sortBy != "price" && sortBy != "popularity" {
+		return nil, status.Errorf(codes.InvalidArgument, "sort_by must be date, price or popularity")
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+
+	filter := repository.EventSearchFilter{
+		Query:         strings.TrimSpace(req.GetQuery()),
+		City:          req.GetCity(),
+		Country:       req.GetCountry(),
+		MinPrice:      int(req.GetMinPrice()),
+		MaxPrice:      int(req.GetMaxPrice()),
+		FreeOnly:      req.GetFreeOnly(),
+		OnlyAvailable: req.GetOnlyAvailable(),
+		SortBy:        sortBy,
+		Limit:         pageSize + 1,
+	}
+
+	if req.GetDateFrom() != nil {
+		from := req.GetDateFrom().AsTime()
+		filter.DateFrom = &from
+	}
+	if req.GetDateTo() != nil {
+		to := req.GetDateTo().AsTime()
+		filter.DateTo = &to
+	}
+
+	if req.GetCursor() != "" {
+		if err := applySearchCursor(&filter, req.GetCursor()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %v", err)
+		}
+	}
+
+	events, details, err := s.clientRepo.SearchEvents(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search events: %v", err)
+	}
+
+	detailsMap := make(map[uuid.UUID]models.EventDetails)
+	for _, detail := range details {
+		detailsMap[detail.EventID] = detail
+	}
+
+	nextCursor := ""
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		last := events[len(events)-1]
+		nextCursor = encodeSearchCursor(sortBy, last, detailsMap[last.EventID])
+	}
+
+	tiersMap, err := s.ticketTiersByEvent(ctx, events)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	now := time.Now()
+	var eventList []*pb.UpcomingEvent
+	for _, event := range events {
+		eventList = append(eventList, upcomingEventToPB(event, detailsMap[event.EventID], tiersMap[event.EventID], now))
+	}
+
+	return &pb.SearchEventsResponse{
+		Events:     eventList,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+const (
+	defaultNearbyRadiusKm = 25.0
+	maxNearbyRadiusKm     = 500.0
+	maxNearbyEvents       = 50
+)
+
+func (s *ClientService) nearbyEvents(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]models.Event, map[uuid.UUID]models.EventDetails, map[uuid.UUID]float64, error) {
+	nearby, err := s.clientRepo.GetEventsNearby(ctx, lat, lng, radiusKm, limit)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var eventIDs []uuid.UUID
+	distances := make(map[uuid.UUID]float64)
+	for _, n := range nearby {
+		eventIDs = append(eventIDs, n.EventID)
+		distances[n.EventID] = n.DistanceKm
+	}
+
+	events, details, err := s.clientRepo.GetEventsByIDs(ctx, eventIDs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	eventsMap := make(map[uuid.UUID]models.Event)
+	for _, event := range events {
+		eventsMap[event.EventID] = event
+	}
+
+	ordered := make([]models.Event, 0, len(eventIDs))
+	for _, id := range eventIDs {
+		if event, ok := eventsMap[id]; ok {
+			ordered = append(ordered, event)
+		}
+	}
+
+	detailsMap := make(map[uuid.UUID]models.EventDetails)
+	for _, detail := range details {
+		detailsMap[detail.EventID] = detail
+	}
+
+	return ordered, detailsMap, distances, nil
+}
+
+func (s *ClientService) GetNearbyEvents(ctx context.Context, req *pb.GetNearbyEventsRequest) (*pb.GetNearbyEventsResponse, error) {
+	lat, lng := req.GetLatitude(), req.GetLongitude()
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid coordinates")
+	}
+
+	radius := req.GetRadiusKm()
+	if radius <= 0 {
+		radius = defaultNearbyRadiusKm
+	}
+	if radius > maxNearbyRadiusKm {
+		return nil, status.Errorf(codes.InvalidArgument, "radius cannot exceed %.0f km", maxNearbyRadiusKm)
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxNearbyEvents {
+		limit = maxNearbyEvents
+	}
+
+	events, detailsMap, distances, err := s.nearbyEvents(ctx, lat, lng, radius, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch nearby events: %v", err)
+	}
+
+	tiersMap, err := s.ticketTiersByEvent(ctx, events)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	now := time.Now()
+	var eventList []*pb.NearbyEvent
+	for _, event := range events {
+		eventList = append(eventList, &pb.NearbyEvent{
+			Event:      upcomingEventToPB(event, detailsMap[event.EventID], tiersMap[event.EventID], now),
+			DistanceKm: distances[event.EventID],
+		})
+	}
+
+	return &pb.GetNearbyEventsResponse{
+		Events: eventList,
+	}, nil
+}
+
+func (s *ClientService) authorizeEventHost(ctx context.Context, eventID, hostID string) (*models.Event, error) {
+	hostUUID, err := uuid.Parse(hostID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse host_id")
+	}
+
+	event, err := s.clientRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "event not found: %v", err)
+	}
+
+	if event.HostedBy != hostUUID {
+		return nil, status.Errorf(codes.PermissionDenied, "event is not hosted by the user")
+	}
+
+	return event, nil
+}
+
+func attendeeFilterFromRequest(statusFilter, checkIn string) (repository.AttendeeFilter, error) {
+	filter := repository.AttendeeFilter{Status: statusFilter}
+
+	switch checkIn {
+	case "", "all":
+	case "checked_in":
+		checkedIn := true
+		filter.CheckedIn = &checkedIn
+	case "not_checked_in":
+		checkedIn := false
+		filter.CheckedIn = &checkedIn
+	default:
+		return filter, status.Errorf(codes.InvalidArgument, "check_in must be all, checked_in or not_checked_in")
+	}
+
+	return filter, nil
+}
+
+func (s *ClientService) GetEventAttendees(ctx context.Context, req *pb.GetEventAttendeesRequest) (*pb.GetEventAttendeesResponse, error) {
+	event, _, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permViewAttendees)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := attendeeFilterFromRequest(req.GetStatus(), req.GetCheckIn())
+	if err != nil {
+		return nil, err
+	}
+
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+
+	filter.Offset = (page - 1) * pageSize
+	filter.Limit = pageSize
+
+	holders, total, err := s.clientRepo.GetEventAttendees(ctx, event.EventID.String(), filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch attendees: %v", err)
+	}
+
+	var attendees []*pb.Attendee
+	for _, holder := range holders {
+		attendee := &pb.Attendee{
+			TicketId:  holder.TicketID,
+			ClientId:  holder.ClientID.String(),
+			FirstName: holder.FirstName,
+			LastName:  holder.LastName,
+			Email:     holder.Email,
+			TierName:  holder.TierName,
+			Status:    holder.Status,
+			CheckedIn: holder.IsScanned,
+			BookedAt:  timestamppb.New(holder.CreatedAt),
+		}
+		if holder.ScannedAt != nil {
+			attendee.CheckedInAt = timestamppb.New(*holder.ScannedAt)
+		}
+		attendees = append(attendees, attendee)
+	}
+
+	return &pb.GetEventAttendeesResponse{
+		Attendees: attendees,
+		Total:     int32(total),
+		Page:      int32(page),
+		PageSize:  int32(pageSize),
+	}, nil
+}
+
+const attendeeExportBatchSize = 500
+
+func (s *ClientService) ExportEventAttendees(req *pb.ExportEventAttendeesRequest, stream pb.ClientService_ExportEventAttendeesServer) error {
+	ctx := stream.Context()
+
+	event, actorID, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permViewAttendees)
+	if err != nil {
+		return err
+	}
+
+	s.recordEventAudit(ctx, event.EventID, actorID, "attendees_exported", fmt.Sprintf("status=%q check_in=%q", req.GetStatus(), req.GetCheckIn()))
+
+	filter, err := attendeeFilterFromRequest(req.GetStatus(), req.GetCheckIn())
+	if err != nil {
+		return err
+	}
+	filter.Limit = attendeeExportBatchSize
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{"Ticket ID", "First Name", "Last Name", "Email", "Tier", "Status", "Checked In", "Checked In At"}
+	if err := writer.Write(header); err != nil {
+		return status.Errorf(codes.Internal, "failed to write csv: %v", err)
+	}
+
+	for {
+		holders, _, err := s.clientRepo.GetEventAttendees(ctx, event.EventID.String(), filter)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to fetch attendees: %v", err)
+		}
+
+		for _, holder := range holders {
+			checkedInAt := ""
+			if holder.ScannedAt != nil {
+				checkedInAt = holder.ScannedAt.Format(time.RFC3339)
+			}
+
+			row := []string{
+				holder.TicketID,
+				csvSafe(holder.FirstName),
+				csvSafe(holder.LastName),
+				csvSafe(holder.Email),
+				csvSafe(holder.TierName),
+				holder.Status,
+				strconv.FormatBool(holder.IsScanned),
+				checkedInAt,
+			}
+			if err := writer.Write(row); err != nil {
+				return status.Errorf(codes.Internal, "failed to write csv: %v", err)
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return status.Errorf(codes.Internal, "failed to write csv: %v", err)
+		}
+
+		if buf.Len() > 0 {
+			if err := stream.Send(&pb.ExportEventAttendeesChunk{Data: buf.Bytes()}); err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+
+		if len(holders) < filter.Limit {
+			return nil
+		}
+		filter.After = &holders[len(holders)-1]
+	}
+}
+
+// csvSafe stops spreadsheet apps from evaluating user-supplied cells as formulas.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+const (
+	defaultEventCommissionRate = 0.10
+	defaultPaymentFeeRate      = 0.029
+)
+
+func (s *ClientService) eventCommissionRate() float64 {
+	if s.config.EVENT_COMMISSION_RATE > 0 && s.config.EVENT_COMMISSION_RATE < 1 {
+		return s.config.EVENT_COMMISSION_RATE
+	}
+	return defaultEventCommissionRate
+}
+
+func (s *ClientService) paymentFeeRate() float64 {
+	if s.config.PAYMENT_FEE_RATE > 0 && s.config.PAYMENT_FEE_RATE < 1 {
+		return s.config.PAYMENT_FEE_RATE
+	}
+	return defaultPaymentFeeRate
+}
+
+func (s *ClientService) GetEventAnalytics(ctx context.Context, req *pb.GetEventAnalyticsRequest) (*pb.GetEventAnalyticsResponse, error) {
+	event, _, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permViewSales)
+	if err != nil {
+		return nil, err
+	}
+
+	from := event.CreatedAt
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	to := time.Now()
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+	if to.Before(from) {
+		return nil, status.Errorf(codes.InvalidArgument, "to must be after from")
+	}
+
+	eventID := event.EventID.String()
+
+	totals, err := s.clientRepo.GetEventSalesTotals(ctx, eventID, from, to)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute sales: %v", err)
+	}
+
+	refunds, refundCount, err := s.clientRepo.GetEventRefundTotal(ctx, eventID, from, to)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute refunds: %v", err)
+	}
+
+	daily, err := s.clientRepo.GetEventDailySales(ctx, eventID, from, to, s.bookingLocation().String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute daily sales: %v", err)
+	}
+
+	tierSales, err := s.clientRepo.GetEventTierSales(ctx, eventID, from, to)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute tier breakdown: %v", err)
+	}
+
+	details, err := s.clientRepo.GetEventDetailsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
+	}
+
+	checkedIn, activeTickets, err := s.clientRepo.CountEventCheckIns(ctx, eventID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count check-ins: %v", err)
+	}
+
+	_, commission, net := s.eventPayout(totals.GrossRevenue, refunds)
+
+	sellThrough := 0.0
+	if details.TicketLimit > 0 {
+		sellThrough = float64(activeTickets) / float64(details.TicketLimit)
+	}
+
+	checkInRate := 0.0
+	if activeTickets > 0 {
+		checkInRate = float64(checkedIn) / float64(activeTickets)
+	}
+
+	var dailyList []*pb.DailySales
+	for _, day := range daily {
+		dailyList = append(dailyList, &pb.DailySales{
+			Date:    day.Day.Format("2006-01-02"),
+			Tickets: int32(day.Tickets),
+			Revenue: int32(day.Revenue),
+		})
+	}
+
+	var tierList []*pb.TierSales
+	for _, tier := range tierSales {
+		tierList = append(tierList, &pb.TierSales{
+			TierId:   tier.TierID.String(),
+			TierName: tier.TierName,
+			Quantity: int32(tier.Quantity),
+			Sold:     int32(tier.Sold),
+			Revenue:  int32(tier.Revenue),
+		})
+	}
+
+	return &pb.GetEventAnalyticsResponse{
+		EventId:            eventID,
+		TicketsSold:        int32(totals.TicketsSold),
+		GrossRevenue:       int32(totals.GrossRevenue),
+		Refunds:            int32(refunds),
+		RefundedTickets:    int32(refundCount),
+		CommissionEstimate: int32(commission),
+		NetPayoutEstimate:  int32(net),
+		TicketLimit:        int32(details.TicketLimit),
+		ActiveTickets:      int32(activeTickets),
+		SellThrough:        sellThrough,
+		CheckedIn:          int32(checkedIn),
+		CheckInRate:        checkInRate,
+		DailySales:         dailyList,
+		Tiers:              tierList,
+	}, nil
+}
+
+func (s *ClientService) notifyEventHolders(ctx context.Context, eventID, title, message string) {
+	holderIDs, err := s.clientRepo.GetEventTicketHolderIDs(ctx, eventID)
+	if err != nil {
+		s.log.Error("Failed to fetch ticket holders for notification:", eventID, err)
+		return
+	}
+
+	for _, holderID := range holderIDs {
+		s.notifyUser(ctx, holderID, title, message)
+	}
+}
+
+// validatePublishable checks an event has what attendees need to book it.
+// Free events are allowed, so only sellable capacity is required.
+func validatePublishable(event *models.Event, details *models.EventDetails, tiers []models.TicketTier) error {
+	var missing []string
+	if details.PosterImage == "" {
+		missing = append(missing, "poster")
+	}
+
+	onSale := false
+	for _, tier := range tiers {
+		if tier.Quantity > 0 {
+			onSale = true
+			break
+		}
+	}
+	if !onSale {
+		missing = append(missing, "ticket tier with tickets")
+	}
+	if event.Location.Address == "" || event.Location.City == "" || event.Location.Country == "" {
+		missing = append(missing, "location")
+	}
+
+	if len(missing) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "event cannot be published, missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func publishState(publishAt *timestamppb.Timestamp, now time.Time) (string, *time.Time, *time.Time) {
+	if publishAt != nil && publishAt.AsTime().After(now) {
+		at := publishAt.AsTime()
+		return "scheduled", &at, nil
+	}
+	return "published", nil, &now
+}
+
+func (s *ClientService) PublishEvent(ctx context.Context, req *pb.PublishEventRequest) (*pb.PublishEventResponse, error) {
+	event, err := s.authorizeEventHost(ctx, req.GetEventId(), req.GetHostId())
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status != "draft" && event.Status != "scheduled" {
+		return nil, status.Errorf(codes.FailedPrecondition, "event is %s and cannot be published", event.Status)
+	}
+
+	details, err := s.clientRepo.GetEventDetailsByEventID(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
+	}
+
+	tiers, err := s.clientRepo.GetTicketTiersByEventIDs(ctx, []uuid.UUID{event.EventID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	if err := validatePublishable(event, details, tiers); err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(eventEndTime(event, details)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "past events cannot be published")
+	}
+
+	eventStatus, publishAt, publishedAt := publishState(req.GetPublishAt(), time.Now())
+	fields := map[string]interface{}{
+		"status":       eventStatus,
+		"publish_at":   publishAt,
+		"published_at": publishedAt,
+	}
+
+	if err := s.clientRepo.UpdateEventFields(ctx, event.EventID.String(), fields, nil); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to publish event: %v", err)
+	}
+
+	if eventStatus == "scheduled" {
+		s.recordEventAudit(ctx, event.EventID, event.HostedBy, "event_scheduled", fmt.Sprintf("publish_at=%s", publishAt.Format(time.RFC3339)))
+	} else {
+		s.recordEventAudit(ctx, event.EventID, event.HostedBy, "event_published", "")
+	}
+
+	message := "Event published"
+	if eventStatus == "scheduled" {
+		message = fmt.Sprintf("Event scheduled for publishing at %s", publishAt.Format(time.RFC3339))
+	}
+
+	return &pb.PublishEventResponse{
+		Message: message,
+		Status:  eventStatus,
+	}, nil
+}
+
+func (s *ClientService) UnpublishEvent(ctx context.Context, req *pb.UnpublishEventRequest) (*pb.UnpublishEventResponse, error) {
+	event, err := s.authorizeEventHost(ctx, req.GetEventId(), req.GetHostId())
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status != "scheduled" && event.Status != "published" {
+		return nil, status.Errorf(codes.FailedPrecondition, "event is %s and cannot be moved back to draft", event.Status)
+	}
+
+	_, ticketsSold, err := s.clientRepo.CountEventCheckIns(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count tickets sold: %v", err)
+	}
+	if ticketsSold > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "events with sold tickets cannot be unpublished, cancel the event instead")
+	}
+
+	fields := map[string]interface{}{
+		"status":     "draft",
+		"publish_at": nil,
+	}
+	if err := s.clientRepo.UpdateEventFields(ctx, event.EventID.String(), fields, nil); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unpublish event: %v", err)
+	}
+
+	s.recordEventAudit(ctx, event.EventID, event.HostedBy, "event_unpublished", fmt.Sprintf("from=%s", event.Status))
+
+	return &pb.UnpublishEventResponse{Message: "Event moved back to draft"}, nil
+}
+
+func (s *ClientService) PreviewEvent(ctx context.Context, req *pb.PreviewEventRequest) (*pb.PreviewEventResponse, error) {
+	event, _, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permEditEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := s.clientRepo.GetEventDetailsByEventID(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
+	}
+
+	tiers, err := s.clientRepo.GetTicketTiersByEventIDs(ctx, []uuid.UUID{event.EventID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	var problems []string
+	if err := validatePublishable(event, details, tiers); err != nil {
+		problems = append(problems, status.Convert(err).Message())
+	}
+
+	return &pb.PreviewEventResponse{
+		Event:           upcomingEventToPB(*event, *details, tiers, time.Now()),
+		Status:          event.Status,
+		PublishProblems: problems,
+	}, nil
+}
+
+func (s *ClientService) ProcessEventLifecycle(ctx context.Context) error {
+	now := time.Now()
+
+	published, err := s.clientRepo.PublishDueEvents(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to publish scheduled events: %w", err)
+	}
+	if published > 0 {
+		s.log.Info("Published scheduled events:", published)
+	}
+
+	archived, err := s.clientRepo.ArchiveEventsBefore(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to archive past events: %w", err)
+	}
+	if archived > 0 {
+		s.log.Info("Archived past events:", archived)
+	}
+
+	return nil
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func optionalUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func recurrenceRuleFromRequest(rule *pb.RecurrenceRule) recurrence.Rule {
+	r := recurrence.Rule{
+		Frequency: rule.GetFrequency(),
+		Interval:  int(rule.GetInterval()),
+		Count:     int(rule.GetCount()),
+	}
+	if rule.GetStartDate() != nil {
+		r.Start = rule.GetStartDate().AsTime()
+	}
+	if rule.GetUntil() != nil {
+		r.Until = rule.GetUntil().AsTime()
+	}
+	for _, d := range rule.GetDates() {
+		r.Dates = append(r.Dates, d.AsTime())
+	}
+	for _, d := range rule.GetExcludedDates() {
+		r.Exclude = append(r.Exclude, d.AsTime())
+	}
+	return r
+}
+
+func (s *ClientService) CreateEventSeries(ctx context.Context, req *pb.CreateEventSeriesRequest) (*pb.CreateEventSeriesResponse, error) {
+	isMasterOfCeremony, err := s.clientRepo.IsMaterofCeremony(ctx, req.GetHostedBy())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find isMasterofCeremony")
+	}
+
+	if !isMasterOfCeremony {
+		return nil, status.Error(codes.Unauthenticated, "The user is not a master of ceremony")
+	}
+
+	hostID, err := uuid.Parse(req.GetHostedBy())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid host id")
+	}
+
+	reqDetails := req.GetEventDetails()
+	rule := recurrenceRuleFromRequest(req.GetRecurrence())
+	dates, err := rule.Occurrences()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid recurrence: %v", err)
+	}
+
+	loc, err := loadEventLocation(req.GetTimezone())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid timezone %q", req.GetTimezone())
+	}
+
+	if dates[0].Before(localDate(time.Now(), loc)) {
+		return nil, status.Errorf(codes.InvalidArgument, "series cannot start in the past")
+	}
+
+	firstStart, firstEnd, err := eventSchedule(timestamppb.New(dates[0]), reqDetails.GetStartTime(), reqDetails.GetEndTime(), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	location := models.Location{
+		Address: req.GetLocation().GetAddress(),
+		City:    req.GetLocation().GetCity(),
+		Country: req.GetLocation().GetCountry(),
+		Lat:     req.GetLocation().GetLatitude(),
+		Lng:     req.GetLocation().GetLongitude(),
+	}
+
+	var venue *models.Venue
+	if req.GetVenueId() != "" {
+		venue, err = s.hostVenue(ctx, req.GetVenueId(), hostID)
+		if err != nil {
+			return nil, err
+		}
+		location = venue.Location
+	}
+
+	var excluded []string
+	for _, d := range rule.Exclude {
+		excluded = append(excluded, d.Format("2006-01-02"))
+	}
+
+	series := &models.EventSeries{
+		SeriesID:      uuid.New(),
+		HostedBy:      hostID,
+		Title:         req.GetTitle(),
+		Frequency:     rule.Frequency,
+		Interval:      rule.Interval,
+		StartDate:     dates[0],
+		Count:         rule.Count,
+		ExcludedDates: strings.Join(excluded, ","),
+	}
+	if !rule.Until.IsZero() {
+		series.Until = &rule.Until
+	}
+
+	// Every occurrence starts at the same local time as the first one and
+	// lasts as long, so daylight saving changes do not shift the schedule.
+	localStart := firstStart.In(loc)
+	duration := firstEnd.Sub(firstStart)
+
+	now := time.Now()
+	var events []models.Event
+	var details []models.EventDetails
+	var tiers []models.TicketTier
+	var eventIDs []string
+
+	for _, date := range dates {
+		eventID := uuid.New()
+		startsAt := onLocalDate(date, localStart, loc)
+		endsAt := startsAt.Add(duration)
+
+		eventTiers, err := ticketTiersFromRequest(eventID, reqDetails.GetTicketTiers(),
+			int(reqDetails.GetPricePerTicket()), int(reqDetails.GetTicketLimit()))
+		if err != nil {
+			return nil, err
+		}
+
+		event := models.Event{
+			EventID:  eventID,
+			Title:    req.GetTitle(),
+			Date:     date,
+			Timezone: loc.String(),
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+			HostedBy: hostID,
+			SeriesID: &series.SeriesID,
+			Location: location,
+		}
+		if venue != nil {
+			event.VenueID = &venue.ID
+		}
+
+		detail := models.EventDetails{
+			EventID:             eventID,
+			Description:         reqDetails.GetDescription(),
+			StartTime:           startsAt.In(loc),
+			EndTime:             endsAt.In(loc),
+			PosterImage:         reqDetails.GetPosterImage(),
+			PricePerTicket:      eventTiers[0].Price,
+			TransferCutoffHours: int(reqDetails.GetTransferCutoffHours()),
+		}
+		for _, tier := range eventTiers {
+			if tier.Price < detail.PricePerTicket {
+				detail.PricePerTicket = tier.Price
+			}
+			detail.TicketLimit += tier.Quantity
+		}
+
+		if err := checkVenueCapacity(venue, detail.TicketLimit); err != nil {
+			return nil, err
+		}
+
+		event.Status = "draft"
+		if !req.GetDraft() {
+			if err := validatePublishable(&event, &detail, eventTiers); err != nil {
+				return nil, err
+			}
+			event.Status, event.PublishAt, event.PublishedAt = publishState(req.GetPublishAt(), now)
+		}
+
+		events = append(events, event)
+		details = append(details, detail)
+		tiers = append(tiers, eventTiers...)
+		eventIDs = append(eventIDs, eventID.String())
+	}
+
+	// Every occurrence is validated against the source poster first, so the
+	// upload only happens once the series is known to be valid.
+	publicID := ""
+	if reqDetails.GetPosterImage() != "" {
+		uploadedURL, result, err := cloudinary.UploadImage(reqDetails.GetPosterImage())
+		if err != nil {
+			s.log.Error("failed to upload image to cloudinary %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to upload image to cloudinary %v", err)
+		}
+		publicID = result.PublicID
+		for i := range details {
+			details[i].PosterImage, details[i].PosterPublicID = uploadedURL, publicID
+		}
+	}
+
+	if err := s.clientRepo.CreateEventSeries(ctx, series, events, details, tiers); err != nil {
+		s.log.Error("Error creating event series: %v", err)
+
+		if publicID != "" {
+			if err := cloudinary.DeleteImage(publicID); err != nil {
+				s.log.Error("Failed to delete event poster:", publicID, err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create event series: %v", err)
+	}
+
+	return &pb.CreateEventSeriesResponse{
+		Message:  fmt.Sprintf("Event series created with %d occurrences", len(events)),
+		SeriesId: series.SeriesID.String(),
+		EventIds: eventIDs,
+	}, nil
+}
+
+func (s *ClientService) GetEventSeries(ctx context.Context, req *pb.GetEventSeriesRequest) (*pb.GetEventSeriesResponse, error) {
+	series, err := s.clientRepo.GetEventSeriesByID(ctx, req.GetSeriesId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "event series not found: %v", err)
+	}
+
+	events, details, err := s.clientRepo.GetSeriesEvents(ctx, series.SeriesID.String(), time.Now(), []string{"published"})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch series events: %v", err)
+	}
+
+	detailsMap := make(map[uuid.UUID]models.EventDetails)
+	for _, d := range details {
+		detailsMap[d.EventID] = d
+	}
+
+	tiersMap, err := s.ticketTiersByEvent(ctx, events)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	now := time.Now()
+	var occurrences []*pb.UpcomingEvent
+	for _, event := range events {
+		detail, ok := detailsMap[event.EventID]
+		if !ok {
+			continue
+		}
+		occurrences = append(occurrences, upcomingEventToPB(event, detail, tiersMap[event.EventID], now))
+	}
+
+	return &pb.GetEventSeriesResponse{
+		SeriesId:    series.SeriesID.String(),
+		Title:       series.Title,
+		Frequency:   series.Frequency,
+		HostedBy:    series.HostedBy.String(),
+		Occurrences: occurrences,
+	}, nil
+}
+
+const (
+	roleCoHost    = "co_host"
+	roleDoorStaff = "door_staff"
+
+	permEditEvent     = "edit_event"
+	permViewSales     = "view_sales"
+	permViewAttendees = "view_attendees"
+	permCheckIn       = "check_in"
+)
+
+var eventRolePermissions = map[string]map[string]bool{
+	roleCoHost: {
+		permEditEvent:     true,
+		permViewSales:     true,
+		permViewAttendees: true,
+		permCheckIn:       true,
+	},
+	roleDoorStaff: {
+		permCheckIn: true,
+	},
+}
+
+// authorizeEventAccess allows the event host and accepted members whose role
+// grants the permission. Owner-only operations keep using authorizeEventHost.
+func (s *ClientService) authorizeEventAccess(ctx context.Context, eventID, userID, permission string) (*models.Event, uuid.UUID, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, uuid.Nil, status.Errorf(codes.InvalidArgument, "failed to parse user id")
+	}
+
+	event, err := s.clientRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, uuid.Nil, status.Errorf(codes.NotFound, "event not found: %v", err)
+	}
+
+	if event.HostedBy == userUUID {
+		return event, userUUID, nil
+	}
+
+	member, err := s.clientRepo.GetEventMember(ctx, event.EventID.String(), userUUID.String())
+	if err != nil {
+		return nil, uuid.Nil, status.Errorf(codes.Internal, "failed to fetch event role: %v", err)
+	}
+
+	if member == nil || member.Status != "accepted" || !eventRolePermissions[member.Role][permission] {
+		return nil, uuid.Nil, status.Errorf(codes.PermissionDenied, "user is not allowed to %s for this event", strings.ReplaceAll(permission, "_", " "))
+	}
+
+	return event, userUUID, nil
+}
+
+func (s *ClientService) recordEventAudit(ctx context.Context, eventID, actorID uuid.UUID, action, details string) {
+	entry := &models.EventAuditLog{
+		EventID: eventID,
+		ActorID: actorID,
+		Action:  action,
+		Details: details,
+	}
+
+	if err := s.clientRepo.CreateEventAuditLog(ctx, entry); err != nil {
+		s.log.Error("Failed to record event audit log: %v", err)
+	}
+}
+
+func (s *ClientService) InviteEventMember(ctx context.Context, req *pb.InviteEventMemberRequest) (*pb.InviteEventMemberResponse, error) {
+	event, err := s.authorizeEventHost(ctx, req.GetEventId(), req.GetHostId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := eventRolePermissions[req.GetRole()]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "role must be %s or %s", roleCoHost, roleDoorStaff)
+	}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse user_id")
+	}
+
+	if userID == event.HostedBy {
+		return nil, status.Errorf(codes.InvalidArgument, "the host already has full access to the event")
+	}
+
+	if _, err := s.clientRepo.GetUserDetailsByID(ctx, userID.String()); err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+	}
+
+	member, err := s.clientRepo.GetEventMember(ctx, event.EventID.String(), userID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event member: %v", err)
+	}
+
+	if member == nil {
+		member = &models.EventMember{
+			EventID: event.EventID,
+			UserID:  userID,
+		}
+	} else if member.Status == "accepted" && member.Role == req.GetRole() {
+		return nil, status.Errorf(codes.AlreadyExists, "user is already a %s for this event", member.Role)
+	}
+
+	// Changing the role of an existing member sends a fresh invitation.
+	member.Role = req.GetRole()
+	member.Status = "pending"
+	member.InvitedBy = event.HostedBy
+	member.RespondedAt = nil
+
+	if err := s.clientRepo.SaveEventMember(ctx, member); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save invitation: %v", err)
+	}
+
+	s.recordEventAudit(ctx, event.EventID, event.HostedBy, "member_invited", fmt.Sprintf("user %s as %s", userID, member.Role))
+	s.notifyUser(ctx, userID, "Event invitation",
+		fmt.Sprintf("You have been invited to help with %s as %s.", event.Title, strings.ReplaceAll(member.Role, "_", " ")))
+
+	return &pb.InviteEventMemberResponse{
+		Message: "Invitation sent",
+	}, nil
+}
+
+func (s *ClientService) RespondToEventInvitation(ctx context.Context, req *pb.RespondToEventInvitationRequest) (*pb.RespondToEventInvitationResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse user_id")
+	}
+
+	event, err := s.clientRepo.GetEventByID(ctx, req.GetEventId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "event not found: %v", err)
+	}
+
+	member, err := s.clientRepo.GetEventMember(ctx, event.EventID.String(), userID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch invitation: %v", err)
+	}
+
+	if member == nil || member.Status != "pending" {
+		return nil, status.Errorf(codes.NotFound, "no pending invitation for this event")
+	}
+
+	now := time.Now()
+	member.Status = "declined"
+	if req.GetAccept() {
+		member.Status = "accepted"
+	}
+	member.RespondedAt = &now
+
+	if err := s.clientRepo.SaveEventMember(ctx, member); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update invitation: %v", err)
+	}
+
+	s.recordEventAudit(ctx, event.EventID, userID, "invitation_"+member.Status, member.Role)
+	s.notifyUser(ctx, event.HostedBy, "Event invitation "+member.Status,
+		fmt.Sprintf("Your %s invitation for %s was %s.", strings.ReplaceAll(member.Role, "_", " "), event.Title, member.Status))
+
+	return &pb.RespondToEventInvitationResponse{
+		Message: "Invitation " + member.Status,
+		Role:    member.Role,
+	}, nil
+}
+
+func (s *ClientService) RemoveEventMember(ctx context.Context, req *pb.RemoveEventMemberRequest) (*pb.RemoveEventMemberResponse, error) {
+	event, err := s.authorizeEventHost(ctx, req.GetEventId(), req.GetHostId())
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.clientRepo.GetEventMember(ctx, event.EventID.String(), req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event member: %v", err)
+	}
+
+	if member == nil || (member.Status != "pending" && member.Status != "accepted") {
+		return nil, status.Errorf(codes.NotFound, "user is not a member of this event")
+	}
+
+	member.Status = "revoked"
+	if err := s.clientRepo.SaveEventMember(ctx, member); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove member: %v", err)
+	}
+
+	s.recordEventAudit(ctx, event.EventID, event.HostedBy, "member_removed", fmt.Sprintf("user %s as %s", member.UserID, member.Role))
+
+	return &pb.RemoveEventMemberResponse{
+		Message: "Member removed",
+	}, nil
+}
+
+func (s *ClientService) GetEventMembers(ctx context.Context, req *pb.GetEventMembersRequest) (*pb.GetEventMembersResponse, error) {
+	event, _, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permEditEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.clientRepo.GetEventMembers(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event members: %v", err)
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+
+	userDetails, err := s.clientRepo.GetUserDetailsByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch member details: %v", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(userDetails))
+	for _, details := range userDetails {
+		names[details.UserID] = details.FirstName + " " + details.LastName
+	}
+
+	var memberList []*pb.EventMember
+	for _, member := range members {
+		name := names[member.UserID]
+
+		memberList = append(memberList, &pb.EventMember{
+			UserId:    member.UserID.String(),
+			Name:      name,
+			Role:      member.Role,
+			Status:    member.Status,
+			InvitedAt: timestamppb.New(member.CreatedAt),
+		})
+	}
+
+	return &pb.GetEventMembersResponse{
+		HostId:  event.HostedBy.String(),
+		Members: memberList,
+	}, nil
+}
+
+const maxAuditPageSize = 100
+
+func (s *ClientService) GetEventAuditLog(ctx context.Context, req *pb.GetEventAuditLogRequest) (*pb.GetEventAuditLogResponse, error) {
+	event, err := s.authorizeEventHost(ctx, req.GetEventId(), req.GetHostId())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxAuditPageSize {
+		limit = maxAuditPageSize
+	}
+
+	entries, total, err := s.clientRepo.GetEventAuditLogs(ctx, event.EventID.String(), limit, int(req.GetOffset()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch audit log: %v", err)
+	}
+
+	var entryList []*pb.EventAuditEntry
+	for _, entry := range entries {
+		entryList = append(entryList, &pb.EventAuditEntry{
+			ActorId:   entry.ActorID.String(),
+			Action:    entry.Action,
+			Details:   entry.Details,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+		})
+	}
+
+	return &pb.GetEventAuditLogResponse{
+		Entries: entryList,
+		Total:   int32(total),
+	}, nil
+}
+
+const maxEventDuration = 31 * 24 * time.Hour
+
+func loadEventLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+func eventLocation(event *models.Event) *time.Location {
+	loc, err := loadEventLocation(event.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func localEventTime(event *models.Event, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(eventLocation(event)).Format(time.RFC3339)
+}
+
+// localDate is the calendar day of t in loc, stored as a UTC midnight the
+// way the date column expects it.
+func localDate(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func onLocalDate(day, clock time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+}
+
+func localDayOffset(from, to time.Time, loc *time.Location) int {
+	return int(localDate(to, loc).Sub(localDate(from, loc)).Hours() / 24)
+}
+
+// eventSchedule resolves the absolute start and end of an event. Start and
+// end are instants; older clients that send a calendar date with clock-only
+// times get those times placed on the date in the event's timezone, rolling
+// the end into the next day when it is earlier than the start. A date
+// without times is treated as an all-day event.
+func eventSchedule(date, start, end *timestamppb.Timestamp, loc *time.Location) (time.Time, time.Time, error) {
+	if start == nil && date == nil {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "event start time is required")
+	}
+
+	var startsAt, endsAt time.Time
+	if start == nil {
+		startsAt = onLocalDate(date.AsTime(), time.Time{}, loc)
+	} else {
+		startsAt = start.AsTime()
+	}
+	if end == nil {
+		endsAt = onLocalDate(startsAt.In(loc), time.Time{}, loc).AddDate(0, 0, 1)
+	} else {
+		endsAt = end.AsTime()
+	}
+
+	if date != nil && start != nil && !localDate(startsAt, loc).Equal(localDate(date.AsTime(), time.UTC)) {
+		startsAt = onLocalDate(date.AsTime(), startsAt.UTC(), loc)
+		if end != nil {
+			endsAt = onLocalDate(date.AsTime(), endsAt.UTC(), loc)
+		} else {
+			endsAt = onLocalDate(startsAt.In(loc), time.Time{}, loc).AddDate(0, 0, 1)
+		}
+		if endsAt.Before(startsAt) {
+			endsAt = endsAt.AddDate(0, 0, 1)
+		}
+	}
+
+	if !endsAt.After(startsAt) {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "end time must be after start time")
+	}
+	if endsAt.Sub(startsAt) > maxEventDuration {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "events cannot last longer than %d days", int(maxEventDuration.Hours()/24))
+	}
+
+	return startsAt, endsAt, nil
+}
+
+// editedEventSchedule applies the schedule paths of an edit to the event.
+// Moving an event to another date or timezone keeps its local start and end
+// times; start and end times sent with the edit are instants.
+func editedEventSchedule(event *models.Event, req *pb.EditEventRequest, paths map[string]bool) (*time.Location, time.Time, time.Time, error) {
+	loc := eventLocation(event)
+	startsAt, endsAt := event.StartsAt, event.EndsAt
+
+	if paths["timezone"] {
+		newLoc, err := loadEventLocation(req.GetTimezone())
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "invalid timezone %q", req.GetTimezone())
+		}
+		startsAt = onLocalDate(startsAt.In(loc), startsAt.In(loc), newLoc)
+		endsAt = onLocalDate(endsAt.In(loc), endsAt.In(loc), newLoc)
+		loc = newLoc
+	}
+
+	if paths["date"] {
+		duration := endsAt.Sub(startsAt)
+		startsAt = onLocalDate(req.GetDate().AsTime(), startsAt.In(loc), loc)
+		endsAt = startsAt.Add(duration)
+	}
+
+	if paths["event_details.start_time"] {
+		startsAt = req.GetEventDetails().GetStartTime().AsTime()
+	}
+	if paths["event_details.end_time"] {
+		endsAt = req.GetEventDetails().GetEndTime().AsTime()
+	}
+
+	if !endsAt.After(startsAt) {
+		return nil, time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "end time must be after start time")
+	}
+	if endsAt.Sub(startsAt) > maxEventDuration {
+		return nil, time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "events cannot last longer than %d days", int(maxEventDuration.Hours()/24))
+	}
+
+	return loc, startsAt, endsAt, nil
+}
+
+const (
+	eventMediaFolder      = "event_gallery"
+	maxEventMediaItems    = 20
+	maxEventVideoBytes    = 50 << 20
+	maxEventVideoDuration = 3 * time.Minute
+)
+
+// eventMediaVariants are the Cloudinary transformations offered for every
+// gallery item. Video variants are delivered as still frames.
+var eventMediaVariants = []struct {
+	name           string
+	transformation string
+}{
+	{"thumbnail", "c_fill,g_auto,w_320,h_180,q_auto"},
+	{"small", "c_limit,w_640,q_auto"},
+	{"medium", "c_limit,w_1280,q_auto"},
+	{"large", "c_limit,w_1920,q_auto"},
+}
+
+func (s *ClientService) eventMediaToPB(media models.EventMedia) *pb.EventMedia {
+	format := ""
+	if media.ResourceType == "video" {
+		format = "jpg"
+	}
+
+	var variants []*pb.MediaVariant
+	for _, v := range eventMediaVariants {
+		url, err := cloudinary.TransformedURL(media.PublicID, media.ResourceType, v.transformation+",f_auto", format)
+		if err != nil {
+			s.log.Error("Failed to build media variant:", media.PublicID, v.name, err)
+			continue
+		}
+		variants = append(variants, &pb.MediaVariant{Name: v.name, Url: url})
+	}
+
+	return &pb.EventMedia{
+		MediaId:      media.ID.String(),
+		Url:          media.URL,
+		ResourceType: media.ResourceType,
+		Format:       media.Format,
+		Width:        int32(media.Width),
+		Height:       int32(media.Height),
+		Caption:      media.Caption,
+		Position:     int32(media.Position),
+		IsCover:      media.IsCover,
+		Variants:     variants,
+		CreatedAt:    timestamppb.New(media.CreatedAt),
+	}
+}
+
+// deleteEventAsset destroys the Cloudinary asset once no poster or gallery
+// item refers to it any more.
+func (s *ClientService) deleteEventAsset(ctx context.Context, publicID, resourceType string) {
+	refs, err := s.clientRepo.CountAssetReferences(ctx, publicID)
+	if err != nil || refs > 0 {
+		return
+	}
+
+	if err := cloudinary.DeleteMedia(publicID, resourceType); err != nil {
+		s.log.Error("Failed to delete media asset:", publicID, err)
+	}
+}
+
+func (s *ClientService) AddEventMedia(ctx context.Context, req *pb.AddEventMediaRequest) (*pb.AddEventMediaResponse, error) {
+	event, actorID, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permEditEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetFile() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "file is required")
+	}
+
+	existing, err := s.clientRepo.GetEventMedia(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event media: %v", err)
+	}
+
+	if len(existing) >= maxEventMediaItems {
+		return nil, status.Errorf(codes.ResourceExhausted, "an event can have at most %d media items", maxEventMediaItems)
+	}
+
+	if req.GetSetAsCover() && cloudinary.IsVideoSource(req.GetFile()) {
+		return nil, status.Errorf(codes.InvalidArgument, "only images can be used as the cover")
+	}
+
+	result, err := cloudinary.UploadMedia(req.GetFile(), eventMediaFolder)
+	if err != nil {
+		s.log.Error("failed to upload media to cloudinary %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to upload media to cloudinary %v", err)
+	}
+
+	if result.ResourceType != "image" && result.ResourceType != "video" {
+		s.deleteEventAsset(ctx, result.PublicID, result.ResourceType)
+		return nil, status.Errorf(codes.InvalidArgument, "only images and videos can be added to the gallery")
+	}
+
+	if result.ResourceType == "video" && result.Bytes > maxEventVideoBytes {
+		s.deleteEventAsset(ctx, result.PublicID, result.ResourceType)
+		return nil, status.Errorf(codes.InvalidArgument, "videos must be smaller than %d MB", maxEventVideoBytes>>20)
+	}
+
+	if result.ResourceType == "video" && cloudinary.Duration(result) > maxEventVideoDuration.Seconds() {
+		s.deleteEventAsset(ctx, result.PublicID, result.ResourceType)
+		return nil, status.Errorf(codes.InvalidArgument, "videos must be shorter than %d minutes", int(maxEventVideoDuration.Minutes()))
+	}
+
+	// Catches videos whose source did not give the type away before upload.
+	if req.GetSetAsCover() && result.ResourceType != "image" {
+		s.deleteEventAsset(ctx, result.PublicID, result.ResourceType)
+		return nil, status.Errorf(codes.InvalidArgument, "only images can be used as the cover")
+	}
+
+	media := &models.EventMedia{
+		EventID:      event.EventID,
+		PublicID:     result.PublicID,
+		URL:          result.SecureURL,
+		ResourceType: result.ResourceType,
+		Format:       result.Format,
+		Width:        result.Width,
+		Height:       result.Height,
+		Bytes:        result.Bytes,
+		Caption:      req.GetCaption(),
+		Position:     len(existing),
+		UploadedBy:   actorID,
+	}
+
+	if err := s.clientRepo.CreateEventMedia(ctx, media); err != nil {
+		s.deleteEventAsset(ctx, result.PublicID, result.ResourceType)
+		return nil, status.Errorf(codes.Internal, "failed to save event media: %v", err)
+	}
+
+	if req.GetSetAsCover() {
+		details, err := s.clientRepo.GetEventDetailsByEventID(ctx, event.EventID.String())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
+		}
+
+		if err := s.clientRepo.SetEventCover(ctx, event.EventID, media); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set cover: %v", err)
+		}
+		media.IsCover = true
+
+		if details.PosterPublicID != "" {
+			s.deleteEventAsset(ctx, details.PosterPublicID, "image")
+		}
+	}
+
+	s.recordEventAudit(ctx, event.EventID, actorID, "media_added", media.ID.String())
+
+	return &pb.AddEventMediaResponse{
+		Media: s.eventMediaToPB(*media),
+	}, nil
+}
+
+func (s *ClientService) UpdateEventMedia(ctx context.Context, req *pb.UpdateEventMediaRequest) (*pb.UpdateEventMediaResponse, error) {
+	event, actorID, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permEditEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.clientRepo.GetEventMediaByID(ctx, event.EventID.String(), req.GetMediaId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "media not found: %v", err)
+	}
+
+	if (req.GetCaption() != "" || req.GetClearCaption()) && req.GetCaption() != media.Caption {
+		if err := s.clientRepo.UpdateEventMediaCaption(ctx, media.ID, req.GetCaption()); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update caption: %v", err)
+		}
+		media.Caption = req.GetCaption()
+	}
+
+	if req.GetMakeCover() && !media.IsCover {
+		if media.ResourceType != "image" {
+			return nil, status.Errorf(codes.InvalidArgument, "only images can be used as the cover")
+		}
+
+		details, err := s.clientRepo.GetEventDetailsByEventID(ctx, event.EventID.String())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch event details: %v", err)
+		}
+
+		if err := s.clientRepo.SetEventCover(ctx, event.EventID, media); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set cover: %v", err)
+		}
+		media.IsCover = true
+
+		if details.PosterPublicID != "" {
+			s.deleteEventAsset(ctx, details.PosterPublicID, "image")
+		}
+	}
+
+	s.recordEventAudit(ctx, event.EventID, actorID, "media_updated", media.ID.String())
+
+	return &pb.UpdateEventMediaResponse{
+		Media: s.eventMediaToPB(*media),
+	}, nil
+}
+
+func (s *ClientService) ReorderEventMedia(ctx context.Context, req *pb.ReorderEventMediaRequest) (*pb.ReorderEventMediaResponse, error) {
+	event, actorID, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permEditEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.clientRepo.GetEventMedia(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event media: %v", err)
+	}
+
+	known := make(map[uuid.UUID]bool, len(existing))
+	for _, media := range existing {
+		known[media.ID] = true
+	}
+
+	if len(req.GetMediaIds()) != len(existing) {
+		return nil, status.Errorf(codes.InvalidArgument, "media_ids must list all %d media items of the event", len(existing))
+	}
+
+	mediaIDs := make([]uuid.UUID, 0, len(req.GetMediaIds()))
+	for _, id := range req.GetMediaIds() {
+		mediaID, err := uuid.Parse(id)
+		if err != nil || !known[mediaID] {
+			return nil, status.Errorf(codes.InvalidArgument, "media %s does not belong to the event", id)
+		}
+		delete(known, mediaID)
+		mediaIDs = append(mediaIDs, mediaID)
+	}
+
+	if err := s.clientRepo.ReorderEventMedia(ctx, event.EventID, mediaIDs); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reorder media: %v", err)
+	}
+
+	s.recordEventAudit(ctx, event.EventID, actorID, "media_reordered", strings.Join(req.GetMediaIds(), ","))
+
+	return &pb.ReorderEventMediaResponse{
+		Message: "Gallery order updated",
+	}, nil
+}
+
+func (s *ClientService) DeleteEventMedia(ctx context.Context, req *pb.DeleteEventMediaRequest) (*pb.DeleteEventMediaResponse, error) {
+	event, actorID, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetHostId(), permEditEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.clientRepo.GetEventMediaByID(ctx, event.EventID.String(), req.GetMediaId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "media not found: %v", err)
+	}
+
+	// Removing the cover promotes the next image so listings keep a poster.
+	var cover *models.EventMedia
+	if media.IsCover {
+		existing, err := s.clientRepo.GetEventMedia(ctx, event.EventID.String())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch event media: %v", err)
+		}
+
+		for i := range existing {
+			if existing[i].ID != media.ID && existing[i].ResourceType == "image" {
+				cover = &existing[i]
+				break
+			}
+		}
+
+		if cover == nil && (event.Status == "published" || event.Status == "scheduled") {
+			return nil, status.Errorf(codes.FailedPrecondition, "the cover of a published event cannot be removed until another image is added")
+		}
+	}
+
+	if err := s.clientRepo.DeleteEventMedia(ctx, media); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete media: %v", err)
+	}
+
+	if media.IsCover {
+		if err := s.clientRepo.SetEventCover(ctx, event.EventID, cover); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update cover: %v", err)
+		}
+	}
+
+	s.deleteEventAsset(ctx, media.PublicID, media.ResourceType)
+	s.recordEventAudit(ctx, event.EventID, actorID, "media_deleted", media.ID.String())
+
+	return &pb.DeleteEventMediaResponse{
+		Message: "Media deleted",
+	}, nil
+}
+
+func (s *ClientService) GetEventMedia(ctx context.Context, req *pb.GetEventMediaRequest) (*pb.GetEventMediaResponse, error) {
+	event, err := s.clientRepo.GetEventByID(ctx, req.GetEventId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "event not found: %v", err)
+	}
+
+	if event.Status != "published" && event.Status != "archived" {
+		if _, _, err := s.authorizeEventAccess(ctx, req.GetEventId(), req.GetViewerId(), permEditEvent); err != nil {
+			return nil, err
+		}
+	}
+
+	media, err := s.clientRepo.GetEventMedia(ctx, event.EventID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch event media: %v", err)
+	}
+
+	var mediaList []*pb.EventMedia
+	for _, item := range media {
+		mediaList = append(mediaList, s.eventMediaToPB(item))
+	}
+
+	return &pb.GetEventMediaResponse{
+		Media: mediaList,
+	}, nil
+}
+
+const venuePhotoFolder = "venue_photos"
+
+func (s *ClientService) hostVenue(ctx context.Context, venueID string, hostID uuid.UUID) (*models.Venue, error) {
+	venue, err := s.clientRepo.GetVenueByID(ctx, venueID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "venue not found: %v", err)
+	}
+
+	if venue.OwnerID != hostID {
+		return nil, status.Errorf(codes.PermissionDenied, "venue is not saved by the host")
+	}
+
+	return venue, nil
+}
+
+func checkVenueCapacity(venue *models.Venue, ticketLimit int) error {
+	if venue == nil || venue.Capacity <= 0 {
+		return nil
+	}
+
+	if ticketLimit > venue.Capacity {
+		return status.Errorf(codes.FailedPrecondition, "ticket limit of %d exceeds the capacity of %s (%d)", ticketLimit, venue.Name, venue.Capacity)
+	}
+	return nil
+}
+
+func (s *ClientService) uploadVenuePhotos(venueID uuid.UUID, files []string, position int) ([]models.VenuePhoto, error) {
+	var photos []models.VenuePhoto
+	for i, file := range files {
+		url, result, err := cloudinary.UploadToFolder(file, venuePhotoFolder)
+		if err != nil {
+			s.deleteVenuePhotos(photos)
+			return nil, status.Errorf(codes.Internal, "failed to upload venue photo: %v", err)
+		}
+
+		photos = append(photos, models.VenuePhoto{
+			VenueID:  venueID,
+			PublicID: result.PublicID,
+			URL:      url,
+			Position: position + i,
+		})
+	}
+	return photos, nil
+}
+
+func (s *ClientService) deleteVenuePhotos(photos []models.VenuePhoto) {
+	for _, photo := range photos {
+		if err := cloudinary.DeleteImage(photo.PublicID); err != nil {
+			s.log.Error("Failed to delete venue photo:", photo.PublicID, err)
+		}
+	}
+}
+
+func venueToPB(venue *models.Venue) *pb.Venue {
+	var photos []*pb.VenuePhoto
+	for _, photo := range venue.Photos {
+		photos = append(photos, &pb.VenuePhoto{
+			PhotoId: photo.ID.String(),
+			Url:     photo.URL,
+		})
+	}
+
+	return &pb.Venue{
+		VenueId: venue.ID.String(),
+		Name:    venue.Name,
+		Location: &pb.Location{
+			Address:   venue.Location.Address,
+			City:      venue.Location.City,
+			Country:   venue.Location.Country,
+			Latitude:  venue.Location.Lat,
+			Longitude: venue.Location.Lng,
+		},
+		Capacity:           int32(venue.Capacity),
+		AccessibilityNotes: venue.AccessibilityNotes,
+		Photos:             photos,
+	}
+}
+
+func (s *ClientService) CreateVenue(ctx context.Context, req *pb.CreateVenueRequest) (*pb.CreateVenueResponse, error) {
+	isMasterOfCeremony, err := s.clientRepo.IsMaterofCeremony(ctx, req.GetHostId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find isMasterofCeremony")
+	}
+
+	if !isMasterOfCeremony {
+		return nil, status.Error(codes.Unauthenticated, "The user is not a master of ceremony")
+	}
+
+	hostID, err := uuid.Parse(req.GetHostId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse host_id")
+	}
+
+	if req.GetName() == "" || req.GetLocation().GetAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "venue name and address are required")
+	}
+
+	if req.GetCapacity() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "capacity cannot be negative")
+	}
+
+	venue := &models.Venue{
+		ID:      uuid.New(),
+		OwnerID: hostID,
+		Name:    req.GetName(),
+		Location: models.Location{
+			Address: req.GetLocation().GetAddress(),
+			City:    req.GetLocation().GetCity(),
+			Country: req.GetLocation().GetCountry(),
+			Lat:     req.GetLocation().GetLatitude(),
+			Lng:     req.GetLocation().GetLongitude(),
+		},
+		Capacity:           int(req.GetCapacity()),
+		AccessibilityNotes: req.GetAccessibilityNotes(),
+	}
+
+	venue.Photos, err = s.uploadVenuePhotos(venue.ID, req.GetPhotos(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clientRepo.CreateVenue(ctx, venue); err != nil {
+		s.deleteVenuePhotos(venue.Photos)
+		return nil, status.Errorf(codes.Internal, "failed to create venue: %v", err)
+	}
+
+	return &pb.CreateVenueResponse{
+		Venue: venueToPB(venue),
+	}, nil
+}
+
+func (s *ClientService) UpdateVenue(ctx context.Context, req *pb.UpdateVenueRequest) (*pb.UpdateVenueResponse, error) {
+	hostID, err := uuid.Parse(req.GetHostId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse host_id")
+	}
+
+	venue, err := s.hostVenue(ctx, req.GetVenueId(), hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetName() != "" {
+		venue.Name = req.GetName()
+	}
+	if req.GetAccessibilityNotes() != "" || req.GetClearAccessibilityNotes() {
+		venue.AccessibilityNotes = req.GetAccessibilityNotes()
+	}
+
+	moved := false
+	if req.GetLocation() != nil {
+		location := models.Location{
+			Address: req.GetLocation().GetAddress(),
+			City:    req.GetLocation().GetCity(),
+			Country: req.GetLocation().GetCountry(),
+			Lat:     req.GetLocation().GetLatitude(),
+			Lng:     req.GetLocation().GetLongitude(),
+		}
+		moved = location != venue.Location
+		venue.Location = location
+	}
+
+	if req.GetCapacity() != 0 {
+		maxLimit, err := s.clientRepo.GetVenueMaxTicketLimit(ctx, venue.ID.String())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check upcoming events: %v", err)
+		}
+		if int(req.GetCapacity()) < maxLimit {
+			return nil, status.Errorf(codes.FailedPrecondition, "an upcoming event at this venue sells %d tickets, capacity cannot be lower", maxLimit)
+		}
+		venue.Capacity = int(req.GetCapacity())
+	} else if req.GetClearCapacity() {
+		// No capacity means events at the venue are not capped.
+		venue.Capacity = 0
+	}
+
+	var affected []models.Event
+	if moved {
+		affected, _, err = s.clientRepo.GetVenueEvents(ctx, venue.ID.String(), []string{"draft", "scheduled", "published"})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fetch venue events: %v", err)
+		}
+	}
+
+	if err := s.clientRepo.UpdateVenue(ctx, venue, moved); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update venue: %v", err)
+	}
+
+	for _, event := range affected {
+		s.notifyEventHolders(ctx, event.EventID.String(), "Event updated",
+			fmt.Sprintf("The venue address for %s has changed. Please check the event details.", event.Title))
+	}
+
+	return &pb.UpdateVenueResponse{
+		Venue: venueToPB(venue),
+	}, nil
+}
+
+func (s *ClientService) AddVenuePhotos(ctx context.Context, req *pb.AddVenuePhotosRequest) (*pb.AddVenuePhotosResponse, error) {
+	hostID, err := uuid.Parse(req.GetHostId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse host_id")
+	}
+
+	venue, err := s.hostVenue(ctx, req.GetVenueId(), hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	photos, err := s.uploadVenuePhotos(venue.ID, req.GetPhotos(), len(venue.Photos))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clientRepo.CreateVenuePhotos(ctx, photos); err != nil {
+		s.deleteVenuePhotos(photos)
+		return nil, status.Errorf(codes.Internal, "failed to save venue photos: %v", err)
+	}
+
+	venue.Photos = append(venue.Photos, photos...)
+	return &pb.AddVenuePhotosResponse{
+		Venue: venueToPB(venue),
+	}, nil
+}
+
+func (s *ClientService) DeleteVenuePhoto(ctx context.Context, req *pb.DeleteVenuePhotoRequest) (*pb.DeleteVenuePhotoResponse, error) {
+	hostID, err := uuid.Parse(req.GetHostId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse host_id")
+	}
+
+	venue, err := s.hostVenue(ctx, req.GetVenueId(), hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	photo, err := s.clientRepo.DeleteVenuePhoto(ctx, venue.ID.String(), req.GetPhotoId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "venue photo not found: %v", err)
+	}
+
+	if err := cloudinary.DeleteImage(photo.PublicID); err != nil {
+		s.log.Error("Failed to delete venue photo:", photo.PublicID, err)
+	}
+
+	return &pb.DeleteVenuePhotoResponse{
+		Message: "Photo deleted",
+	}, nil
+}
+
+func (s *ClientService) GetHostVenues(ctx context.Context, req *pb.GetHostVenuesRequest) (*pb.GetHostVenuesResponse, error) {
+	if _, err := uuid.Parse(req.GetUserId()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse user_id")
+	}
+
+	if req.GetUserId() != req.GetHostId() {
+		isAdmin, err := s.clientRepo.IsAdmin(ctx, req.GetUserId())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check user role: %v", err)
+		}
+		if !isAdmin {
+			return nil, status.Errorf(codes.PermissionDenied, "venues can only be listed by their host")
+		}
+	}
+
+	venues, err := s.clientRepo.GetVenuesByOwner(ctx, req.GetHostId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch venues: %v", err)
+	}
+
+	var venueList []*pb.Venue
+	for i := range venues {
+		venueList = append(venueList, venueToPB(&venues[i]))
+	}
+
+	return &pb.GetHostVenuesResponse{
+		Venues: venueList,
+	}, nil
+}
+
+func (s *ClientService) GetVenue(ctx context.Context, req *pb.GetVenueRequest) (*pb.GetVenueResponse, error) {
+	venue, err := s.clientRepo.GetVenueByID(ctx, req.GetVenueId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "venue not found: %v", err)
+	}
+
+	events, details, err := s.clientRepo.GetVenueEvents(ctx, venue.ID.String(), []string{"published"})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch venue events: %v", err)
+	}
+
+	detailsMap := make(map[uuid.UUID]models.EventDetails)
+	for _, d := range details {
+		detailsMap[d.EventID] = d
+	}
+
+	tiersMap, err := s.ticketTiersByEvent(ctx, events)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch ticket tiers: %v", err)
+	}
+
+	now := time.Now()
+	var upcoming []*pb.UpcomingEvent
+	for _, event := range events {
+		detail, ok := detailsMap[event.EventID]
+		if !ok {
+			continue
+		}
+		upcoming = append(upcoming, upcomingEventToPB(event, detail, tiersMap[event.EventID], now))
+	}
+
+	return &pb.GetVenueResponse{
+		Venue:          venueToPB(venue),
+		UpcomingEvents: upcoming,
 	}, nil
 }